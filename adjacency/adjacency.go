@@ -1,28 +1,77 @@
+// Package adjacency provides the keyboard graphs used to find spatial patterns,
+// like qwerty or 7896 walks.
+//
+// The graphs of the built-in layouts are generated by cmd/build-adjacency-graphs from
+// the descriptions in the layouts directory. More layouts can be parsed at runtime
+// with ParseLayout and registered with Register.
 package adjacency
 
+import "sync"
+
+// Graph maps each character of a keyboard to the keys around it, in clockwise order.
 type Graph struct {
 	Graph         map[string][]string
 	Name          string
 	AverageDegree float64
 }
 
-// Graphs exports the adjacency graphs by name
-var Graphs = make(map[string]*Graph)
-
-func init() {
-	initGraph("qwerty", adjacencyGraphQwerty)
-	initGraph("dvorak", adjacencyGraphDvorak)
-	initGraph("keypad", adjacencyGraphKeypad)
-	initGraph("mac_keypad", adjacencyGraphMacKeypad)
-}
-
-func initGraph(name string, data map[string][]string) {
-	g := &Graph{
+// NewGraph returns the graph with the given name and adjacency lists.
+func NewGraph(name string, data map[string][]string) *Graph {
+	return &Graph{
 		Name:          name,
 		Graph:         data,
 		AverageDegree: calculateAvgDegree(data),
 	}
-	Graphs[name] = g
+}
+
+var (
+	graphsOnce sync.Once
+	graphsMu   sync.RWMutex
+)
+
+// Graphs holds the adjacency graphs by name, as returned by Get and set by Register.
+//
+// Deprecated: the built-in graphs are no longer built at init but on first use, so
+// Graphs only holds them once Get, Register or Preload has been called, and it must
+// not be modified concurrently with them. Use Get and Register instead.
+var Graphs = make(map[string]*Graph)
+
+// loadGraphs builds the built-in adjacency graphs on first use, keeping the graphs
+// added to Graphs beforehand.
+func loadGraphs() {
+	graphsOnce.Do(func() {
+		graphsMu.Lock()
+		defer graphsMu.Unlock()
+		for _, g := range builtinGraphs {
+			if _, ok := Graphs[g.name]; !ok {
+				Graphs[g.name] = NewGraph(g.name, g.graph())
+			}
+		}
+	})
+}
+
+// Preload builds the built-in graphs now rather than on first use.
+func Preload() {
+	loadGraphs()
+}
+
+// Get returns the adjacency graph with the given name, or nil if there is none.
+// The built-in graphs are built on first use.
+func Get(name string) *Graph {
+	loadGraphs()
+	graphsMu.RLock()
+	defer graphsMu.RUnlock()
+	return Graphs[name]
+}
+
+// Register makes g available through Get, replacing any graph with the same name.
+// Spatial matches on a registered graph are scored with its own number of keys and
+// average degree.
+func Register(g *Graph) {
+	loadGraphs()
+	graphsMu.Lock()
+	defer graphsMu.Unlock()
+	Graphs[g.Name] = g
 }
 
 func calculateAvgDegree(g map[string][]string) float64 {
@@ -1,233 +1,879 @@
+// Code generated by cmd/build-adjacency-graphs from the layouts directory. DO NOT EDIT.
+
 package adjacency
 
-// generated by scripts/build_keyboard_adjacency_graphs.py
-var adjacencyGraphQwerty = map[string][]string{
-	`$`: {`3#`, ``, ``, `5%`, `rR`, `eE`},
-	`(`: {`8*`, ``, ``, `0)`, `oO`, `iI`},
-	`,`: {`mM`, `kK`, `lL`, `.>`, ``, ``},
-	`0`: {`9(`, ``, ``, `-_`, `pP`, `oO`},
-	`4`: {`3#`, ``, ``, `5%`, `rR`, `eE`},
-	`8`: {`7&`, ``, ``, `9(`, `iI`, `uU`},
-	`<`: {`mM`, `kK`, `lL`, `.>`, ``, ``},
-	`@`: {`1!`, ``, ``, `3#`, `wW`, `qQ`},
-	`D`: {`sS`, `eE`, `rR`, `fF`, `cC`, `xX`},
-	`H`: {`gG`, `yY`, `uU`, `jJ`, `nN`, `bB`},
-	`L`: {`kK`, `oO`, `pP`, `;:`, `.>`, `,<`},
-	`P`: {`oO`, `0)`, `-_`, `[{`, `;:`, `lL`},
-	`T`: {`rR`, `5%`, `6^`, `yY`, `gG`, `fF`},
-	`X`: {`zZ`, `sS`, `dD`, `cC`, ``, ``},
-	`\`: {`]}`, ``, ``, ``, ``, ``},
-	"`": {``, ``, ``, `1!`, ``, ``},
-	`d`: {`sS`, `eE`, `rR`, `fF`, `cC`, `xX`},
-	`h`: {`gG`, `yY`, `uU`, `jJ`, `nN`, `bB`},
-	`l`: {`kK`, `oO`, `pP`, `;:`, `.>`, `,<`},
-	`p`: {`oO`, `0)`, `-_`, `[{`, `;:`, `lL`},
-	`t`: {`rR`, `5%`, `6^`, `yY`, `gG`, `fF`},
-	`x`: {`zZ`, `sS`, `dD`, `cC`, ``, ``},
-	`|`: {`]}`, ``, ``, ``, ``, ``},
-	`#`: {`2@`, ``, ``, `4$`, `eE`, `wW`},
-	`'`: {`;:`, `[{`, `]}`, ``, ``, `/?`},
-	`+`: {`-_`, ``, ``, ``, `]}`, `[{`},
-	`/`: {`.>`, `;:`, `'"`, ``, ``, ``},
-	`3`: {`2@`, ``, ``, `4$`, `eE`, `wW`},
-	`7`: {`6^`, ``, ``, `8*`, `uU`, `yY`},
-	`;`: {`lL`, `pP`, `[{`, `'"`, `/?`, `.>`},
-	`?`: {`.>`, `;:`, `'"`, ``, ``, ``},
-	`C`: {`xX`, `dD`, `fF`, `vV`, ``, ``},
-	`G`: {`fF`, `tT`, `yY`, `hH`, `bB`, `vV`},
-	`K`: {`jJ`, `iI`, `oO`, `lL`, `,<`, `mM`},
-	`O`: {`iI`, `9(`, `0)`, `pP`, `lL`, `kK`},
-	`S`: {`aA`, `wW`, `eE`, `dD`, `xX`, `zZ`},
-	`W`: {`qQ`, `2@`, `3#`, `eE`, `sS`, `aA`},
-	`[`: {`pP`, `-_`, `=+`, `]}`, `'"`, `;:`},
-	`_`: {`0)`, ``, ``, `=+`, `[{`, `pP`},
-	`c`: {`xX`, `dD`, `fF`, `vV`, ``, ``},
-	`g`: {`fF`, `tT`, `yY`, `hH`, `bB`, `vV`},
-	`k`: {`jJ`, `iI`, `oO`, `lL`, `,<`, `mM`},
-	`o`: {`iI`, `9(`, `0)`, `pP`, `lL`, `kK`},
-	`s`: {`aA`, `wW`, `eE`, `dD`, `xX`, `zZ`},
-	`w`: {`qQ`, `2@`, `3#`, `eE`, `sS`, `aA`},
-	`{`: {`pP`, `-_`, `=+`, `]}`, `'"`, `;:`},
-	`"`: {`;:`, `[{`, `]}`, ``, ``, `/?`},
-	`&`: {`6^`, ``, ``, `8*`, `uU`, `yY`},
-	`*`: {`7&`, ``, ``, `9(`, `iI`, `uU`},
-	`.`: {`,<`, `lL`, `;:`, `/?`, ``, ``},
-	`2`: {`1!`, ``, ``, `3#`, `wW`, `qQ`},
-	`6`: {`5%`, ``, ``, `7&`, `yY`, `tT`},
-	`:`: {`lL`, `pP`, `[{`, `'"`, `/?`, `.>`},
-	`>`: {`,<`, `lL`, `;:`, `/?`, ``, ``},
-	`B`: {`vV`, `gG`, `hH`, `nN`, ``, ``},
-	`F`: {`dD`, `rR`, `tT`, `gG`, `vV`, `cC`},
-	`J`: {`hH`, `uU`, `iI`, `kK`, `mM`, `nN`},
-	`N`: {`bB`, `hH`, `jJ`, `mM`, ``, ``},
-	`R`: {`eE`, `4$`, `5%`, `tT`, `fF`, `dD`},
-	`V`: {`cC`, `fF`, `gG`, `bB`, ``, ``},
-	`Z`: {``, `aA`, `sS`, `xX`, ``, ``},
-	`^`: {`5%`, ``, ``, `7&`, `yY`, `tT`},
-	`b`: {`vV`, `gG`, `hH`, `nN`, ``, ``},
-	`f`: {`dD`, `rR`, `tT`, `gG`, `vV`, `cC`},
-	`j`: {`hH`, `uU`, `iI`, `kK`, `mM`, `nN`},
-	`n`: {`bB`, `hH`, `jJ`, `mM`, ``, ``},
-	`r`: {`eE`, `4$`, `5%`, `tT`, `fF`, `dD`},
-	`v`: {`cC`, `fF`, `gG`, `bB`, ``, ``},
-	`z`: {``, `aA`, `sS`, `xX`, ``, ``},
-	`~`: {``, ``, ``, `1!`, ``, ``},
-	`!`: {"`~", ``, ``, `2@`, `qQ`, ``},
-	`%`: {`4$`, ``, ``, `6^`, `tT`, `rR`},
-	`)`: {`9(`, ``, ``, `-_`, `pP`, `oO`},
-	`-`: {`0)`, ``, ``, `=+`, `[{`, `pP`},
-	`1`: {"`~", ``, ``, `2@`, `qQ`, ``},
-	`5`: {`4$`, ``, ``, `6^`, `tT`, `rR`},
-	`9`: {`8*`, ``, ``, `0)`, `oO`, `iI`},
-	`=`: {`-_`, ``, ``, ``, `]}`, `[{`},
-	`A`: {``, `qQ`, `wW`, `sS`, `zZ`, ``},
-	`E`: {`wW`, `3#`, `4$`, `rR`, `dD`, `sS`},
-	`I`: {`uU`, `8*`, `9(`, `oO`, `kK`, `jJ`},
-	`M`: {`nN`, `jJ`, `kK`, `,<`, ``, ``},
-	`Q`: {``, `1!`, `2@`, `wW`, `aA`, ``},
-	`U`: {`yY`, `7&`, `8*`, `iI`, `jJ`, `hH`},
-	`Y`: {`tT`, `6^`, `7&`, `uU`, `hH`, `gG`},
-	`]`: {`[{`, `=+`, ``, `\|`, ``, `'"`},
-	`a`: {``, `qQ`, `wW`, `sS`, `zZ`, ``},
-	`e`: {`wW`, `3#`, `4$`, `rR`, `dD`, `sS`},
-	`i`: {`uU`, `8*`, `9(`, `oO`, `kK`, `jJ`},
-	`m`: {`nN`, `jJ`, `kK`, `,<`, ``, ``},
-	`q`: {``, `1!`, `2@`, `wW`, `aA`, ``},
-	`u`: {`yY`, `7&`, `8*`, `iI`, `jJ`, `hH`},
-	`y`: {`tT`, `6^`, `7&`, `uU`, `hH`, `gG`},
-	`}`: {`[{`, `=+`, ``, `\|`, ``, `'"`},
+// builtinGraphs lists the graphs of the built-in layouts.
+var builtinGraphs = []struct {
+	name  string
+	graph func() map[string][]string
+}{
+	{"azerty", adjacencyGraphAzerty},
+	{"colemak", adjacencyGraphColemak},
+	{"dvorak", adjacencyGraphDvorak},
+	{"hebrew", adjacencyGraphHebrew},
+	{"jcuken", adjacencyGraphJcuken},
+	{"keypad", adjacencyGraphKeypad},
+	{"mac_keypad", adjacencyGraphMacKeypad},
+	{"qwerty", adjacencyGraphQwerty},
+	{"qwertz", adjacencyGraphQwertz},
+	{"workman", adjacencyGraphWorkman},
+}
+
+func adjacencyGraphAzerty() map[string][]string {
+	return map[string][]string{
+		`!`: {`:/`, `mM`, `ù%`, ``, ``, ``},
+		`"`: {`é2~`, ``, ``, `'4{`, `eE€`, `zZ`},
+		`#`: {`é2~`, ``, ``, `'4{`, `eE€`, `zZ`},
+		`$`: {`^¨`, `=+}`, ``, ``, `*µ`, `ù%`},
+		`%`: {`mM`, `^¨`, `$£¤`, `*µ`, ``, `!§`},
+		`&`: {``, ``, ``, `é2~`, `aA`, ``},
+		`'`: {`"3#`, ``, ``, `(5[`, `rR`, `eE€`},
+		`(`: {`'4{`, ``, ``, `-6|`, `tT`, `rR`},
+		`)`: {`à0@`, ``, ``, `=+}`, `^¨`, `pP`},
+		`*`: {`ù%`, `$£¤`, ``, ``, ``, ``},
+		`+`: {`)°]`, ``, ``, ``, `$£¤`, `^¨`},
+		`,`: {`nN`, `jJ`, `kK`, `;.`, ``, ``},
+		`-`: {`(5[`, ``, ``, "è7`", `yY`, `tT`},
+		`.`: {`,?`, `kK`, `lL`, `:/`, ``, ``},
+		`/`: {`;.`, `lL`, `mM`, `!§`, ``, ``},
+		`0`: {`ç9`, ``, ``, `)°]`, `pP`, `oO`},
+		`1`: {``, ``, ``, `é2~`, `aA`, ``},
+		`2`: {`&1`, ``, ``, `"3#`, `zZ`, `aA`},
+		`3`: {`é2~`, ``, ``, `'4{`, `eE€`, `zZ`},
+		`4`: {`"3#`, ``, ``, `(5[`, `rR`, `eE€`},
+		`5`: {`'4{`, ``, ``, `-6|`, `tT`, `rR`},
+		`6`: {`(5[`, ``, ``, "è7`", `yY`, `tT`},
+		`7`: {`-6|`, ``, ``, `_8\`, `uU`, `yY`},
+		`8`: {"è7`", ``, ``, `ç9`, `iI`, `uU`},
+		`9`: {`_8\`, ``, ``, `à0@`, `oO`, `iI`},
+		`:`: {`;.`, `lL`, `mM`, `!§`, ``, ``},
+		`;`: {`,?`, `kK`, `lL`, `:/`, ``, ``},
+		`<`: {``, ``, `qQ`, `wW`, ``, ``},
+		`=`: {`)°]`, ``, ``, ``, `$£¤`, `^¨`},
+		`>`: {``, ``, `qQ`, `wW`, ``, ``},
+		`?`: {`nN`, `jJ`, `kK`, `;.`, ``, ``},
+		`@`: {`ç9`, ``, ``, `)°]`, `pP`, `oO`},
+		`A`: {``, `&1`, `é2~`, `zZ`, `qQ`, ``},
+		`B`: {`vV`, `gG`, `hH`, `nN`, ``, ``},
+		`C`: {`xX`, `dD`, `fF`, `vV`, ``, ``},
+		`D`: {`sS`, `eE€`, `rR`, `fF`, `cC`, `xX`},
+		`E`: {`zZ`, `"3#`, `'4{`, `rR`, `dD`, `sS`},
+		`F`: {`dD`, `rR`, `tT`, `gG`, `vV`, `cC`},
+		`G`: {`fF`, `tT`, `yY`, `hH`, `bB`, `vV`},
+		`H`: {`gG`, `yY`, `uU`, `jJ`, `nN`, `bB`},
+		`I`: {`uU`, `_8\`, `ç9`, `oO`, `kK`, `jJ`},
+		`J`: {`hH`, `uU`, `iI`, `kK`, `,?`, `nN`},
+		`K`: {`jJ`, `iI`, `oO`, `lL`, `;.`, `,?`},
+		`L`: {`kK`, `oO`, `pP`, `mM`, `:/`, `;.`},
+		`M`: {`lL`, `pP`, `^¨`, `ù%`, `!§`, `:/`},
+		`N`: {`bB`, `hH`, `jJ`, `,?`, ``, ``},
+		`O`: {`iI`, `ç9`, `à0@`, `pP`, `lL`, `kK`},
+		`P`: {`oO`, `à0@`, `)°]`, `^¨`, `mM`, `lL`},
+		`Q`: {``, `aA`, `zZ`, `sS`, `wW`, `<>`},
+		`R`: {`eE€`, `'4{`, `(5[`, `tT`, `fF`, `dD`},
+		`S`: {`qQ`, `zZ`, `eE€`, `dD`, `xX`, `wW`},
+		`T`: {`rR`, `(5[`, `-6|`, `yY`, `gG`, `fF`},
+		`U`: {`yY`, "è7`", `_8\`, `iI`, `jJ`, `hH`},
+		`V`: {`cC`, `fF`, `gG`, `bB`, ``, ``},
+		`W`: {`<>`, `qQ`, `sS`, `xX`, ``, ``},
+		`X`: {`wW`, `sS`, `dD`, `cC`, ``, ``},
+		`Y`: {`tT`, `-6|`, "è7`", `uU`, `hH`, `gG`},
+		`Z`: {`aA`, `é2~`, `"3#`, `eE€`, `sS`, `qQ`},
+		`[`: {`'4{`, ``, ``, `-6|`, `tT`, `rR`},
+		`\`: {"è7`", ``, ``, `ç9`, `iI`, `uU`},
+		`]`: {`à0@`, ``, ``, `=+}`, `^¨`, `pP`},
+		`^`: {`pP`, `)°]`, `=+}`, `$£¤`, `ù%`, `mM`},
+		`_`: {"è7`", ``, ``, `ç9`, `iI`, `uU`},
+		"`": {`-6|`, ``, ``, `_8\`, `uU`, `yY`},
+		`a`: {``, `&1`, `é2~`, `zZ`, `qQ`, ``},
+		`b`: {`vV`, `gG`, `hH`, `nN`, ``, ``},
+		`c`: {`xX`, `dD`, `fF`, `vV`, ``, ``},
+		`d`: {`sS`, `eE€`, `rR`, `fF`, `cC`, `xX`},
+		`e`: {`zZ`, `"3#`, `'4{`, `rR`, `dD`, `sS`},
+		`f`: {`dD`, `rR`, `tT`, `gG`, `vV`, `cC`},
+		`g`: {`fF`, `tT`, `yY`, `hH`, `bB`, `vV`},
+		`h`: {`gG`, `yY`, `uU`, `jJ`, `nN`, `bB`},
+		`i`: {`uU`, `_8\`, `ç9`, `oO`, `kK`, `jJ`},
+		`j`: {`hH`, `uU`, `iI`, `kK`, `,?`, `nN`},
+		`k`: {`jJ`, `iI`, `oO`, `lL`, `;.`, `,?`},
+		`l`: {`kK`, `oO`, `pP`, `mM`, `:/`, `;.`},
+		`m`: {`lL`, `pP`, `^¨`, `ù%`, `!§`, `:/`},
+		`n`: {`bB`, `hH`, `jJ`, `,?`, ``, ``},
+		`o`: {`iI`, `ç9`, `à0@`, `pP`, `lL`, `kK`},
+		`p`: {`oO`, `à0@`, `)°]`, `^¨`, `mM`, `lL`},
+		`q`: {``, `aA`, `zZ`, `sS`, `wW`, `<>`},
+		`r`: {`eE€`, `'4{`, `(5[`, `tT`, `fF`, `dD`},
+		`s`: {`qQ`, `zZ`, `eE€`, `dD`, `xX`, `wW`},
+		`t`: {`rR`, `(5[`, `-6|`, `yY`, `gG`, `fF`},
+		`u`: {`yY`, "è7`", `_8\`, `iI`, `jJ`, `hH`},
+		`v`: {`cC`, `fF`, `gG`, `bB`, ``, ``},
+		`w`: {`<>`, `qQ`, `sS`, `xX`, ``, ``},
+		`x`: {`wW`, `sS`, `dD`, `cC`, ``, ``},
+		`y`: {`tT`, `-6|`, "è7`", `uU`, `hH`, `gG`},
+		`z`: {`aA`, `é2~`, `"3#`, `eE€`, `sS`, `qQ`},
+		`{`: {`"3#`, ``, ``, `(5[`, `rR`, `eE€`},
+		`|`: {`(5[`, ``, ``, "è7`", `yY`, `tT`},
+		`}`: {`)°]`, ``, ``, ``, `$£¤`, `^¨`},
+		`~`: {`&1`, ``, ``, `"3#`, `zZ`, `aA`},
+		`£`: {`^¨`, `=+}`, ``, ``, `*µ`, `ù%`},
+		`¤`: {`^¨`, `=+}`, ``, ``, `*µ`, `ù%`},
+		`§`: {`:/`, `mM`, `ù%`, ``, ``, ``},
+		`¨`: {`pP`, `)°]`, `=+}`, `$£¤`, `ù%`, `mM`},
+		`°`: {`à0@`, ``, ``, `=+}`, `^¨`, `pP`},
+		`µ`: {`ù%`, `$£¤`, ``, ``, ``, ``},
+		`à`: {`ç9`, ``, ``, `)°]`, `pP`, `oO`},
+		`ç`: {`_8\`, ``, ``, `à0@`, `oO`, `iI`},
+		`è`: {`-6|`, ``, ``, `_8\`, `uU`, `yY`},
+		`é`: {`&1`, ``, ``, `"3#`, `zZ`, `aA`},
+		`ù`: {`mM`, `^¨`, `$£¤`, `*µ`, ``, `!§`},
+		`€`: {`zZ`, `"3#`, `'4{`, `rR`, `dD`, `sS`},
+	}
+}
+
+func adjacencyGraphColemak() map[string][]string {
+	return map[string][]string{
+		`!`: {"`~", ``, ``, `2@`, `qQ`, ``},
+		`"`: {`oO`, `[{`, `]}`, ``, ``, `/?`},
+		`#`: {`2@`, ``, ``, `4$`, `fF`, `wW`},
+		`$`: {`3#`, ``, ``, `5%`, `pP`, `fF`},
+		`%`: {`4$`, ``, ``, `6^`, `gG`, `pP`},
+		`&`: {`6^`, ``, ``, `8*`, `lL`, `jJ`},
+		`'`: {`oO`, `[{`, `]}`, ``, ``, `/?`},
+		`(`: {`8*`, ``, ``, `0)`, `yY`, `uU`},
+		`)`: {`9(`, ``, ``, `-_`, `;:`, `yY`},
+		`*`: {`7&`, ``, ``, `9(`, `uU`, `lL`},
+		`+`: {`-_`, ``, ``, ``, `]}`, `[{`},
+		`,`: {`mM`, `eE`, `iI`, `.>`, ``, ``},
+		`-`: {`0)`, ``, ``, `=+`, `[{`, `;:`},
+		`.`: {`,<`, `iI`, `oO`, `/?`, ``, ``},
+		`/`: {`.>`, `oO`, `'"`, ``, ``, ``},
+		`0`: {`9(`, ``, ``, `-_`, `;:`, `yY`},
+		`1`: {"`~", ``, ``, `2@`, `qQ`, ``},
+		`2`: {`1!`, ``, ``, `3#`, `wW`, `qQ`},
+		`3`: {`2@`, ``, ``, `4$`, `fF`, `wW`},
+		`4`: {`3#`, ``, ``, `5%`, `pP`, `fF`},
+		`5`: {`4$`, ``, ``, `6^`, `gG`, `pP`},
+		`6`: {`5%`, ``, ``, `7&`, `jJ`, `gG`},
+		`7`: {`6^`, ``, ``, `8*`, `lL`, `jJ`},
+		`8`: {`7&`, ``, ``, `9(`, `uU`, `lL`},
+		`9`: {`8*`, ``, ``, `0)`, `yY`, `uU`},
+		`:`: {`yY`, `0)`, `-_`, `[{`, `oO`, `iI`},
+		`;`: {`yY`, `0)`, `-_`, `[{`, `oO`, `iI`},
+		`<`: {`mM`, `eE`, `iI`, `.>`, ``, ``},
+		`=`: {`-_`, ``, ``, ``, `]}`, `[{`},
+		`>`: {`,<`, `iI`, `oO`, `/?`, ``, ``},
+		`?`: {`.>`, `oO`, `'"`, ``, ``, ``},
+		`@`: {`1!`, ``, ``, `3#`, `wW`, `qQ`},
+		`A`: {``, `qQ`, `wW`, `rR`, `zZ`, ``},
+		`B`: {`vV`, `dD`, `hH`, `kK`, ``, ``},
+		`C`: {`xX`, `sS`, `tT`, `vV`, ``, ``},
+		`D`: {`tT`, `gG`, `jJ`, `hH`, `bB`, `vV`},
+		`E`: {`nN`, `uU`, `yY`, `iI`, `,<`, `mM`},
+		`F`: {`wW`, `3#`, `4$`, `pP`, `sS`, `rR`},
+		`G`: {`pP`, `5%`, `6^`, `jJ`, `dD`, `tT`},
+		`H`: {`dD`, `jJ`, `lL`, `nN`, `kK`, `bB`},
+		`I`: {`eE`, `yY`, `;:`, `oO`, `.>`, `,<`},
+		`J`: {`gG`, `6^`, `7&`, `lL`, `hH`, `dD`},
+		`K`: {`bB`, `hH`, `nN`, `mM`, ``, ``},
+		`L`: {`jJ`, `7&`, `8*`, `uU`, `nN`, `hH`},
+		`M`: {`kK`, `nN`, `eE`, `,<`, ``, ``},
+		`N`: {`hH`, `lL`, `uU`, `eE`, `mM`, `kK`},
+		`O`: {`iI`, `;:`, `[{`, `'"`, `/?`, `.>`},
+		`P`: {`fF`, `4$`, `5%`, `gG`, `tT`, `sS`},
+		`Q`: {``, `1!`, `2@`, `wW`, `aA`, ``},
+		`R`: {`aA`, `wW`, `fF`, `sS`, `xX`, `zZ`},
+		`S`: {`rR`, `fF`, `pP`, `tT`, `cC`, `xX`},
+		`T`: {`sS`, `pP`, `gG`, `dD`, `vV`, `cC`},
+		`U`: {`lL`, `8*`, `9(`, `yY`, `eE`, `nN`},
+		`V`: {`cC`, `tT`, `dD`, `bB`, ``, ``},
+		`W`: {`qQ`, `2@`, `3#`, `fF`, `rR`, `aA`},
+		`X`: {`zZ`, `rR`, `sS`, `cC`, ``, ``},
+		`Y`: {`uU`, `9(`, `0)`, `;:`, `iI`, `eE`},
+		`Z`: {``, `aA`, `rR`, `xX`, ``, ``},
+		`[`: {`;:`, `-_`, `=+`, `]}`, `'"`, `oO`},
+		`\`: {`]}`, ``, ``, ``, ``, ``},
+		`]`: {`[{`, `=+`, ``, `\|`, ``, `'"`},
+		`^`: {`5%`, ``, ``, `7&`, `jJ`, `gG`},
+		`_`: {`0)`, ``, ``, `=+`, `[{`, `;:`},
+		"`": {``, ``, ``, `1!`, ``, ``},
+		`a`: {``, `qQ`, `wW`, `rR`, `zZ`, ``},
+		`b`: {`vV`, `dD`, `hH`, `kK`, ``, ``},
+		`c`: {`xX`, `sS`, `tT`, `vV`, ``, ``},
+		`d`: {`tT`, `gG`, `jJ`, `hH`, `bB`, `vV`},
+		`e`: {`nN`, `uU`, `yY`, `iI`, `,<`, `mM`},
+		`f`: {`wW`, `3#`, `4$`, `pP`, `sS`, `rR`},
+		`g`: {`pP`, `5%`, `6^`, `jJ`, `dD`, `tT`},
+		`h`: {`dD`, `jJ`, `lL`, `nN`, `kK`, `bB`},
+		`i`: {`eE`, `yY`, `;:`, `oO`, `.>`, `,<`},
+		`j`: {`gG`, `6^`, `7&`, `lL`, `hH`, `dD`},
+		`k`: {`bB`, `hH`, `nN`, `mM`, ``, ``},
+		`l`: {`jJ`, `7&`, `8*`, `uU`, `nN`, `hH`},
+		`m`: {`kK`, `nN`, `eE`, `,<`, ``, ``},
+		`n`: {`hH`, `lL`, `uU`, `eE`, `mM`, `kK`},
+		`o`: {`iI`, `;:`, `[{`, `'"`, `/?`, `.>`},
+		`p`: {`fF`, `4$`, `5%`, `gG`, `tT`, `sS`},
+		`q`: {``, `1!`, `2@`, `wW`, `aA`, ``},
+		`r`: {`aA`, `wW`, `fF`, `sS`, `xX`, `zZ`},
+		`s`: {`rR`, `fF`, `pP`, `tT`, `cC`, `xX`},
+		`t`: {`sS`, `pP`, `gG`, `dD`, `vV`, `cC`},
+		`u`: {`lL`, `8*`, `9(`, `yY`, `eE`, `nN`},
+		`v`: {`cC`, `tT`, `dD`, `bB`, ``, ``},
+		`w`: {`qQ`, `2@`, `3#`, `fF`, `rR`, `aA`},
+		`x`: {`zZ`, `rR`, `sS`, `cC`, ``, ``},
+		`y`: {`uU`, `9(`, `0)`, `;:`, `iI`, `eE`},
+		`z`: {``, `aA`, `rR`, `xX`, ``, ``},
+		`{`: {`;:`, `-_`, `=+`, `]}`, `'"`, `oO`},
+		`|`: {`]}`, ``, ``, ``, ``, ``},
+		`}`: {`[{`, `=+`, ``, `\|`, ``, `'"`},
+		`~`: {``, ``, ``, `1!`, ``, ``},
+	}
+}
+
+func adjacencyGraphDvorak() map[string][]string {
+	return map[string][]string{
+		`!`: {"`~", ``, ``, `2@`, `'"`, ``},
+		`"`: {``, `1!`, `2@`, `,<`, `aA`, ``},
+		`#`: {`2@`, ``, ``, `4$`, `.>`, `,<`},
+		`$`: {`3#`, ``, ``, `5%`, `pP`, `.>`},
+		`%`: {`4$`, ``, ``, `6^`, `yY`, `pP`},
+		`&`: {`6^`, ``, ``, `8*`, `gG`, `fF`},
+		`'`: {``, `1!`, `2@`, `,<`, `aA`, ``},
+		`(`: {`8*`, ``, ``, `0)`, `rR`, `cC`},
+		`)`: {`9(`, ``, ``, `[{`, `lL`, `rR`},
+		`*`: {`7&`, ``, ``, `9(`, `cC`, `gG`},
+		`+`: {`/?`, `]}`, ``, `\|`, ``, `-_`},
+		`,`: {`'"`, `2@`, `3#`, `.>`, `oO`, `aA`},
+		`-`: {`sS`, `/?`, `=+`, ``, ``, `zZ`},
+		`.`: {`,<`, `3#`, `4$`, `pP`, `eE`, `oO`},
+		`/`: {`lL`, `[{`, `]}`, `=+`, `-_`, `sS`},
+		`0`: {`9(`, ``, ``, `[{`, `lL`, `rR`},
+		`1`: {"`~", ``, ``, `2@`, `'"`, ``},
+		`2`: {`1!`, ``, ``, `3#`, `,<`, `'"`},
+		`3`: {`2@`, ``, ``, `4$`, `.>`, `,<`},
+		`4`: {`3#`, ``, ``, `5%`, `pP`, `.>`},
+		`5`: {`4$`, ``, ``, `6^`, `yY`, `pP`},
+		`6`: {`5%`, ``, ``, `7&`, `fF`, `yY`},
+		`7`: {`6^`, ``, ``, `8*`, `gG`, `fF`},
+		`8`: {`7&`, ``, ``, `9(`, `cC`, `gG`},
+		`9`: {`8*`, ``, ``, `0)`, `rR`, `cC`},
+		`:`: {``, `aA`, `oO`, `qQ`, ``, ``},
+		`;`: {``, `aA`, `oO`, `qQ`, ``, ``},
+		`<`: {`'"`, `2@`, `3#`, `.>`, `oO`, `aA`},
+		`=`: {`/?`, `]}`, ``, `\|`, ``, `-_`},
+		`>`: {`,<`, `3#`, `4$`, `pP`, `eE`, `oO`},
+		`?`: {`lL`, `[{`, `]}`, `=+`, `-_`, `sS`},
+		`@`: {`1!`, ``, ``, `3#`, `,<`, `'"`},
+		`A`: {``, `'"`, `,<`, `oO`, `;:`, ``},
+		`B`: {`xX`, `dD`, `hH`, `mM`, ``, ``},
+		`C`: {`gG`, `8*`, `9(`, `rR`, `tT`, `hH`},
+		`D`: {`iI`, `fF`, `gG`, `hH`, `bB`, `xX`},
+		`E`: {`oO`, `.>`, `pP`, `uU`, `jJ`, `qQ`},
+		`F`: {`yY`, `6^`, `7&`, `gG`, `dD`, `iI`},
+		`G`: {`fF`, `7&`, `8*`, `cC`, `hH`, `dD`},
+		`H`: {`dD`, `gG`, `cC`, `tT`, `mM`, `bB`},
+		`I`: {`uU`, `yY`, `fF`, `dD`, `xX`, `kK`},
+		`J`: {`qQ`, `eE`, `uU`, `kK`, ``, ``},
+		`K`: {`jJ`, `uU`, `iI`, `xX`, ``, ``},
+		`L`: {`rR`, `0)`, `[{`, `/?`, `sS`, `nN`},
+		`M`: {`bB`, `hH`, `tT`, `wW`, ``, ``},
+		`N`: {`tT`, `rR`, `lL`, `sS`, `vV`, `wW`},
+		`O`: {`aA`, `,<`, `.>`, `eE`, `qQ`, `;:`},
+		`P`: {`.>`, `4$`, `5%`, `yY`, `uU`, `eE`},
+		`Q`: {`;:`, `oO`, `eE`, `jJ`, ``, ``},
+		`R`: {`cC`, `9(`, `0)`, `lL`, `nN`, `tT`},
+		`S`: {`nN`, `lL`, `/?`, `-_`, `zZ`, `vV`},
+		`T`: {`hH`, `cC`, `rR`, `nN`, `wW`, `mM`},
+		`U`: {`eE`, `pP`, `yY`, `iI`, `kK`, `jJ`},
+		`V`: {`wW`, `nN`, `sS`, `zZ`, ``, ``},
+		`W`: {`mM`, `tT`, `nN`, `vV`, ``, ``},
+		`X`: {`kK`, `iI`, `dD`, `bB`, ``, ``},
+		`Y`: {`pP`, `5%`, `6^`, `fF`, `iI`, `uU`},
+		`Z`: {`vV`, `sS`, `-_`, ``, ``, ``},
+		`[`: {`0)`, ``, ``, `]}`, `/?`, `lL`},
+		`\`: {`=+`, ``, ``, ``, ``, ``},
+		`]`: {`[{`, ``, ``, ``, `=+`, `/?`},
+		`^`: {`5%`, ``, ``, `7&`, `fF`, `yY`},
+		`_`: {`sS`, `/?`, `=+`, ``, ``, `zZ`},
+		"`": {``, ``, ``, `1!`, ``, ``},
+		`a`: {``, `'"`, `,<`, `oO`, `;:`, ``},
+		`b`: {`xX`, `dD`, `hH`, `mM`, ``, ``},
+		`c`: {`gG`, `8*`, `9(`, `rR`, `tT`, `hH`},
+		`d`: {`iI`, `fF`, `gG`, `hH`, `bB`, `xX`},
+		`e`: {`oO`, `.>`, `pP`, `uU`, `jJ`, `qQ`},
+		`f`: {`yY`, `6^`, `7&`, `gG`, `dD`, `iI`},
+		`g`: {`fF`, `7&`, `8*`, `cC`, `hH`, `dD`},
+		`h`: {`dD`, `gG`, `cC`, `tT`, `mM`, `bB`},
+		`i`: {`uU`, `yY`, `fF`, `dD`, `xX`, `kK`},
+		`j`: {`qQ`, `eE`, `uU`, `kK`, ``, ``},
+		`k`: {`jJ`, `uU`, `iI`, `xX`, ``, ``},
+		`l`: {`rR`, `0)`, `[{`, `/?`, `sS`, `nN`},
+		`m`: {`bB`, `hH`, `tT`, `wW`, ``, ``},
+		`n`: {`tT`, `rR`, `lL`, `sS`, `vV`, `wW`},
+		`o`: {`aA`, `,<`, `.>`, `eE`, `qQ`, `;:`},
+		`p`: {`.>`, `4$`, `5%`, `yY`, `uU`, `eE`},
+		`q`: {`;:`, `oO`, `eE`, `jJ`, ``, ``},
+		`r`: {`cC`, `9(`, `0)`, `lL`, `nN`, `tT`},
+		`s`: {`nN`, `lL`, `/?`, `-_`, `zZ`, `vV`},
+		`t`: {`hH`, `cC`, `rR`, `nN`, `wW`, `mM`},
+		`u`: {`eE`, `pP`, `yY`, `iI`, `kK`, `jJ`},
+		`v`: {`wW`, `nN`, `sS`, `zZ`, ``, ``},
+		`w`: {`mM`, `tT`, `nN`, `vV`, ``, ``},
+		`x`: {`kK`, `iI`, `dD`, `bB`, ``, ``},
+		`y`: {`pP`, `5%`, `6^`, `fF`, `iI`, `uU`},
+		`z`: {`vV`, `sS`, `-_`, ``, ``, ``},
+		`{`: {`0)`, ``, ``, `]}`, `/?`, `lL`},
+		`|`: {`=+`, ``, ``, ``, ``, ``},
+		`}`: {`[{`, ``, ``, ``, `=+`, `/?`},
+		`~`: {``, ``, ``, `1!`, ``, ``},
+	}
+}
+
+func adjacencyGraphHebrew() map[string][]string {
+	return map[string][]string{
+		`!`: {`;~`, ``, ``, `2@`, `/Q`, ``},
+		`"`: {`ף:`, `]}`, `[{`, ``, ``, `.?`},
+		`#`: {`2@`, ``, ``, `4$`, `קE`, `'W`},
+		`$`: {`3#`, ``, ``, `5%`, `רR`, `קE`},
+		`%`: {`4$`, ``, ``, `6^`, `אT`, `רR`},
+		`&`: {`6^`, ``, ``, `8*`, `וU`, `טY`},
+		`'`: {`/Q`, `2@`, `3#`, `קE`, `דS`, `שA`},
+		`(`: {`9)`, ``, ``, `-_`, `פP`, `םO`},
+		`)`: {`8*`, ``, ``, `0(`, `םO`, `ןI`},
+		`*`: {`7&`, ``, ``, `9)`, `ןI`, `וU`},
+		`+`: {`-_`, ``, ``, ``, `[{`, `]}`},
+		`,`: {`ף:`, `]}`, `[{`, ``, ``, `.?`},
+		`-`: {`0(`, ``, ``, `=+`, `]}`, `פP`},
+		`.`: {`ץ<`, `ף:`, `,"`, ``, ``, ``},
+		`/`: {``, `1!`, `2@`, `'W`, `שA`, ``},
+		`0`: {`9)`, ``, ``, `-_`, `פP`, `םO`},
+		`1`: {`;~`, ``, ``, `2@`, `/Q`, ``},
+		`2`: {`1!`, ``, ``, `3#`, `'W`, `/Q`},
+		`3`: {`2@`, ``, ``, `4$`, `קE`, `'W`},
+		`4`: {`3#`, ``, ``, `5%`, `רR`, `קE`},
+		`5`: {`4$`, ``, ``, `6^`, `אT`, `רR`},
+		`6`: {`5%`, ``, ``, `7&`, `טY`, `אT`},
+		`7`: {`6^`, ``, ``, `8*`, `וU`, `טY`},
+		`8`: {`7&`, ``, ``, `9)`, `ןI`, `וU`},
+		`9`: {`8*`, ``, ``, `0(`, `םO`, `ןI`},
+		`:`: {`ךL`, `פP`, `]}`, `,"`, `.?`, `ץ<`},
+		`;`: {``, ``, ``, `1!`, ``, ``},
+		`<`: {`ת>`, `ךL`, `ף:`, `.?`, ``, ``},
+		`=`: {`-_`, ``, ``, ``, `[{`, `]}`},
+		`>`: {`צM`, `לK`, `ךL`, `ץ<`, ``, ``},
+		`?`: {`ץ<`, `ף:`, `,"`, ``, ``, ``},
+		`@`: {`1!`, ``, ``, `3#`, `'W`, `/Q`},
+		`A`: {``, `/Q`, `'W`, `דS`, `זZ`, ``},
+		`B`: {`הV`, `עG`, `יH`, `מN`, ``, ``},
+		`C`: {`סX`, `גD`, `כF`, `הV`, ``, ``},
+		`D`: {`דS`, `קE`, `רR`, `כF`, `בC`, `סX`},
+		`E`: {`'W`, `3#`, `4$`, `רR`, `גD`, `דS`},
+		`F`: {`גD`, `רR`, `אT`, `עG`, `הV`, `בC`},
+		`G`: {`כF`, `אT`, `טY`, `יH`, `נB`, `הV`},
+		`H`: {`עG`, `טY`, `וU`, `חJ`, `מN`, `נB`},
+		`I`: {`וU`, `8*`, `9)`, `םO`, `לK`, `חJ`},
+		`J`: {`יH`, `וU`, `ןI`, `לK`, `צM`, `מN`},
+		`K`: {`חJ`, `ןI`, `םO`, `ךL`, `ת>`, `צM`},
+		`L`: {`לK`, `םO`, `פP`, `ף:`, `ץ<`, `ת>`},
+		`M`: {`מN`, `חJ`, `לK`, `ת>`, ``, ``},
+		`N`: {`נB`, `יH`, `חJ`, `צM`, ``, ``},
+		`O`: {`ןI`, `9)`, `0(`, `פP`, `ךL`, `לK`},
+		`P`: {`םO`, `0(`, `-_`, `]}`, `ף:`, `ךL`},
+		`Q`: {``, `1!`, `2@`, `'W`, `שA`, ``},
+		`R`: {`קE`, `4$`, `5%`, `אT`, `כF`, `גD`},
+		`S`: {`שA`, `'W`, `קE`, `גD`, `סX`, `זZ`},
+		`T`: {`רR`, `5%`, `6^`, `טY`, `עG`, `כF`},
+		`U`: {`טY`, `7&`, `8*`, `ןI`, `חJ`, `יH`},
+		`V`: {`בC`, `כF`, `עG`, `נB`, ``, ``},
+		`W`: {`/Q`, `2@`, `3#`, `קE`, `דS`, `שA`},
+		`X`: {`זZ`, `דS`, `גD`, `בC`, ``, ``},
+		`Y`: {`אT`, `6^`, `7&`, `וU`, `יH`, `עG`},
+		`Z`: {``, `שA`, `דS`, `סX`, ``, ``},
+		`[`: {`]}`, `=+`, ``, `\|`, ``, `,"`},
+		`\`: {`[{`, ``, ``, ``, ``, ``},
+		`]`: {`פP`, `-_`, `=+`, `[{`, `,"`, `ף:`},
+		`^`: {`5%`, ``, ``, `7&`, `טY`, `אT`},
+		`_`: {`0(`, ``, ``, `=+`, `]}`, `פP`},
+		`{`: {`]}`, `=+`, ``, `\|`, ``, `,"`},
+		`|`: {`[{`, ``, ``, ``, ``, ``},
+		`}`: {`פP`, `-_`, `=+`, `[{`, `,"`, `ף:`},
+		`~`: {``, ``, ``, `1!`, ``, ``},
+		`א`: {`רR`, `5%`, `6^`, `טY`, `עG`, `כF`},
+		`ב`: {`סX`, `גD`, `כF`, `הV`, ``, ``},
+		`ג`: {`דS`, `קE`, `רR`, `כF`, `בC`, `סX`},
+		`ד`: {`שA`, `'W`, `קE`, `גD`, `סX`, `זZ`},
+		`ה`: {`בC`, `כF`, `עG`, `נB`, ``, ``},
+		`ו`: {`טY`, `7&`, `8*`, `ןI`, `חJ`, `יH`},
+		`ז`: {``, `שA`, `דS`, `סX`, ``, ``},
+		`ח`: {`יH`, `וU`, `ןI`, `לK`, `צM`, `מN`},
+		`ט`: {`אT`, `6^`, `7&`, `וU`, `יH`, `עG`},
+		`י`: {`עG`, `טY`, `וU`, `חJ`, `מN`, `נB`},
+		`ך`: {`לK`, `םO`, `פP`, `ף:`, `ץ<`, `ת>`},
+		`כ`: {`גD`, `רR`, `אT`, `עG`, `הV`, `בC`},
+		`ל`: {`חJ`, `ןI`, `םO`, `ךL`, `ת>`, `צM`},
+		`ם`: {`ןI`, `9)`, `0(`, `פP`, `ךL`, `לK`},
+		`מ`: {`נB`, `יH`, `חJ`, `צM`, ``, ``},
+		`ן`: {`וU`, `8*`, `9)`, `םO`, `לK`, `חJ`},
+		`נ`: {`הV`, `עG`, `יH`, `מN`, ``, ``},
+		`ס`: {`זZ`, `דS`, `גD`, `בC`, ``, ``},
+		`ע`: {`כF`, `אT`, `טY`, `יH`, `נB`, `הV`},
+		`ף`: {`ךL`, `פP`, `]}`, `,"`, `.?`, `ץ<`},
+		`פ`: {`םO`, `0(`, `-_`, `]}`, `ף:`, `ךL`},
+		`ץ`: {`ת>`, `ךL`, `ף:`, `.?`, ``, ``},
+		`צ`: {`מN`, `חJ`, `לK`, `ת>`, ``, ``},
+		`ק`: {`'W`, `3#`, `4$`, `רR`, `גD`, `דS`},
+		`ר`: {`קE`, `4$`, `5%`, `אT`, `כF`, `גD`},
+		`ש`: {``, `/Q`, `'W`, `דS`, `זZ`, ``},
+		`ת`: {`צM`, `לK`, `ךL`, `ץ<`, ``, ``},
+	}
+}
+
+func adjacencyGraphJcuken() map[string][]string {
+	return map[string][]string{
+		`!`: {`ёЁ`, ``, ``, `2"`, `йЙ`, ``},
+		`"`: {`1!`, ``, ``, `3№`, `цЦ`, `йЙ`},
+		`%`: {`4;`, ``, ``, `6:`, `еЕ`, `кК`},
+		`(`: {`8*`, ``, ``, `0)`, `щЩ`, `шШ`},
+		`)`: {`9(`, ``, ``, `-_`, `зЗ`, `щЩ`},
+		`*`: {`7?`, ``, ``, `9(`, `шШ`, `гГ`},
+		`+`: {`-_`, ``, ``, ``, `ъЪ`, `хХ`},
+		`,`: {`юЮ`, `жЖ`, `эЭ`, ``, ``, ``},
+		`-`: {`0)`, ``, ``, `=+`, `хХ`, `зЗ`},
+		`.`: {`юЮ`, `жЖ`, `эЭ`, ``, ``, ``},
+		`/`: {`ъЪ`, ``, ``, ``, ``, ``},
+		`0`: {`9(`, ``, ``, `-_`, `зЗ`, `щЩ`},
+		`1`: {`ёЁ`, ``, ``, `2"`, `йЙ`, ``},
+		`2`: {`1!`, ``, ``, `3№`, `цЦ`, `йЙ`},
+		`3`: {`2"`, ``, ``, `4;`, `уУ`, `цЦ`},
+		`4`: {`3№`, ``, ``, `5%`, `кК`, `уУ`},
+		`5`: {`4;`, ``, ``, `6:`, `еЕ`, `кК`},
+		`6`: {`5%`, ``, ``, `7?`, `нН`, `еЕ`},
+		`7`: {`6:`, ``, ``, `8*`, `гГ`, `нН`},
+		`8`: {`7?`, ``, ``, `9(`, `шШ`, `гГ`},
+		`9`: {`8*`, ``, ``, `0)`, `щЩ`, `шШ`},
+		`:`: {`5%`, ``, ``, `7?`, `нН`, `еЕ`},
+		`;`: {`3№`, ``, ``, `5%`, `кК`, `уУ`},
+		`=`: {`-_`, ``, ``, ``, `ъЪ`, `хХ`},
+		`?`: {`6:`, ``, ``, `8*`, `гГ`, `нН`},
+		`\`: {`ъЪ`, ``, ``, ``, ``, ``},
+		`_`: {`0)`, ``, ``, `=+`, `хХ`, `зЗ`},
+		`Ё`: {``, ``, ``, `1!`, ``, ``},
+		`А`: {`вВ`, `кК`, `еЕ`, `пП`, `мМ`, `сС`},
+		`Б`: {`ьЬ`, `лЛ`, `дД`, `юЮ`, ``, ``},
+		`В`: {`ыЫ`, `уУ`, `кК`, `аА`, `сС`, `чЧ`},
+		`Г`: {`нН`, `7?`, `8*`, `шШ`, `оО`, `рР`},
+		`Д`: {`лЛ`, `щЩ`, `зЗ`, `жЖ`, `юЮ`, `бБ`},
+		`Е`: {`кК`, `5%`, `6:`, `нН`, `пП`, `аА`},
+		`Ж`: {`дД`, `зЗ`, `хХ`, `эЭ`, `.,`, `юЮ`},
+		`З`: {`щЩ`, `0)`, `-_`, `хХ`, `жЖ`, `дД`},
+		`И`: {`мМ`, `пП`, `рР`, `тТ`, ``, ``},
+		`Й`: {``, `1!`, `2"`, `цЦ`, `фФ`, ``},
+		`К`: {`уУ`, `4;`, `5%`, `еЕ`, `аА`, `вВ`},
+		`Л`: {`оО`, `шШ`, `щЩ`, `дД`, `бБ`, `ьЬ`},
+		`М`: {`сС`, `аА`, `пП`, `иИ`, ``, ``},
+		`Н`: {`еЕ`, `6:`, `7?`, `гГ`, `рР`, `пП`},
+		`О`: {`рР`, `гГ`, `шШ`, `лЛ`, `ьЬ`, `тТ`},
+		`П`: {`аА`, `еЕ`, `нН`, `рР`, `иИ`, `мМ`},
+		`Р`: {`пП`, `нН`, `гГ`, `оО`, `тТ`, `иИ`},
+		`С`: {`чЧ`, `вВ`, `аА`, `мМ`, ``, ``},
+		`Т`: {`иИ`, `рР`, `оО`, `ьЬ`, ``, ``},
+		`У`: {`цЦ`, `3№`, `4;`, `кК`, `вВ`, `ыЫ`},
+		`Ф`: {``, `йЙ`, `цЦ`, `ыЫ`, `яЯ`, ``},
+		`Х`: {`зЗ`, `-_`, `=+`, `ъЪ`, `эЭ`, `жЖ`},
+		`Ц`: {`йЙ`, `2"`, `3№`, `уУ`, `ыЫ`, `фФ`},
+		`Ч`: {`яЯ`, `ыЫ`, `вВ`, `сС`, ``, ``},
+		`Ш`: {`гГ`, `8*`, `9(`, `щЩ`, `лЛ`, `оО`},
+		`Щ`: {`шШ`, `9(`, `0)`, `зЗ`, `дД`, `лЛ`},
+		`Ъ`: {`хХ`, `=+`, ``, `\/`, ``, `эЭ`},
+		`Ы`: {`фФ`, `цЦ`, `уУ`, `вВ`, `чЧ`, `яЯ`},
+		`Ь`: {`тТ`, `оО`, `лЛ`, `бБ`, ``, ``},
+		`Э`: {`жЖ`, `хХ`, `ъЪ`, ``, ``, `.,`},
+		`Ю`: {`бБ`, `дД`, `жЖ`, `.,`, ``, ``},
+		`Я`: {``, `фФ`, `ыЫ`, `чЧ`, ``, ``},
+		`а`: {`вВ`, `кК`, `еЕ`, `пП`, `мМ`, `сС`},
+		`б`: {`ьЬ`, `лЛ`, `дД`, `юЮ`, ``, ``},
+		`в`: {`ыЫ`, `уУ`, `кК`, `аА`, `сС`, `чЧ`},
+		`г`: {`нН`, `7?`, `8*`, `шШ`, `оО`, `рР`},
+		`д`: {`лЛ`, `щЩ`, `зЗ`, `жЖ`, `юЮ`, `бБ`},
+		`е`: {`кК`, `5%`, `6:`, `нН`, `пП`, `аА`},
+		`ж`: {`дД`, `зЗ`, `хХ`, `эЭ`, `.,`, `юЮ`},
+		`з`: {`щЩ`, `0)`, `-_`, `хХ`, `жЖ`, `дД`},
+		`и`: {`мМ`, `пП`, `рР`, `тТ`, ``, ``},
+		`й`: {``, `1!`, `2"`, `цЦ`, `фФ`, ``},
+		`к`: {`уУ`, `4;`, `5%`, `еЕ`, `аА`, `вВ`},
+		`л`: {`оО`, `шШ`, `щЩ`, `дД`, `бБ`, `ьЬ`},
+		`м`: {`сС`, `аА`, `пП`, `иИ`, ``, ``},
+		`н`: {`еЕ`, `6:`, `7?`, `гГ`, `рР`, `пП`},
+		`о`: {`рР`, `гГ`, `шШ`, `лЛ`, `ьЬ`, `тТ`},
+		`п`: {`аА`, `еЕ`, `нН`, `рР`, `иИ`, `мМ`},
+		`р`: {`пП`, `нН`, `гГ`, `оО`, `тТ`, `иИ`},
+		`с`: {`чЧ`, `вВ`, `аА`, `мМ`, ``, ``},
+		`т`: {`иИ`, `рР`, `оО`, `ьЬ`, ``, ``},
+		`у`: {`цЦ`, `3№`, `4;`, `кК`, `вВ`, `ыЫ`},
+		`ф`: {``, `йЙ`, `цЦ`, `ыЫ`, `яЯ`, ``},
+		`х`: {`зЗ`, `-_`, `=+`, `ъЪ`, `эЭ`, `жЖ`},
+		`ц`: {`йЙ`, `2"`, `3№`, `уУ`, `ыЫ`, `фФ`},
+		`ч`: {`яЯ`, `ыЫ`, `вВ`, `сС`, ``, ``},
+		`ш`: {`гГ`, `8*`, `9(`, `щЩ`, `лЛ`, `оО`},
+		`щ`: {`шШ`, `9(`, `0)`, `зЗ`, `дД`, `лЛ`},
+		`ъ`: {`хХ`, `=+`, ``, `\/`, ``, `эЭ`},
+		`ы`: {`фФ`, `цЦ`, `уУ`, `вВ`, `чЧ`, `яЯ`},
+		`ь`: {`тТ`, `оО`, `лЛ`, `бБ`, ``, ``},
+		`э`: {`жЖ`, `хХ`, `ъЪ`, ``, ``, `.,`},
+		`ю`: {`бБ`, `дД`, `жЖ`, `.,`, ``, ``},
+		`я`: {``, `фФ`, `ыЫ`, `чЧ`, ``, ``},
+		`ё`: {``, ``, ``, `1!`, ``, ``},
+		`№`: {`2"`, ``, ``, `4;`, `уУ`, `цЦ`},
+	}
+}
+
+func adjacencyGraphKeypad() map[string][]string {
+	return map[string][]string{
+		`*`: {`/`, ``, ``, ``, `-`, `+`, `9`, `8`},
+		`+`: {`9`, `*`, `-`, ``, ``, ``, ``, `6`},
+		`-`: {`*`, ``, ``, ``, ``, ``, `+`, `9`},
+		`.`: {`0`, `2`, `3`, ``, ``, ``, ``, ``},
+		`/`: {``, ``, ``, ``, `*`, `9`, `8`, `7`},
+		`0`: {``, `1`, `2`, `3`, `.`, ``, ``, ``},
+		`1`: {``, ``, `4`, `5`, `2`, `0`, ``, ``},
+		`2`: {`1`, `4`, `5`, `6`, `3`, `.`, `0`, ``},
+		`3`: {`2`, `5`, `6`, ``, ``, ``, `.`, `0`},
+		`4`: {``, ``, `7`, `8`, `5`, `2`, `1`, ``},
+		`5`: {`4`, `7`, `8`, `9`, `6`, `3`, `2`, `1`},
+		`6`: {`5`, `8`, `9`, `+`, ``, ``, `3`, `2`},
+		`7`: {``, ``, ``, `/`, `8`, `5`, `4`, ``},
+		`8`: {`7`, ``, `/`, `*`, `9`, `6`, `5`, `4`},
+		`9`: {`8`, `/`, `*`, `-`, `+`, ``, `6`, `5`},
+	}
+}
+
+func adjacencyGraphMacKeypad() map[string][]string {
+	return map[string][]string{
+		`*`: {`/`, ``, ``, ``, ``, ``, `-`, `9`},
+		`+`: {`6`, `9`, `-`, ``, ``, ``, ``, `3`},
+		`-`: {`9`, `/`, `*`, ``, ``, ``, `+`, `6`},
+		`.`: {`0`, `2`, `3`, ``, ``, ``, ``, ``},
+		`/`: {`=`, ``, ``, ``, `*`, `-`, `9`, `8`},
+		`0`: {``, `1`, `2`, `3`, `.`, ``, ``, ``},
+		`1`: {``, ``, `4`, `5`, `2`, `0`, ``, ``},
+		`2`: {`1`, `4`, `5`, `6`, `3`, `.`, `0`, ``},
+		`3`: {`2`, `5`, `6`, `+`, ``, ``, `.`, `0`},
+		`4`: {``, ``, `7`, `8`, `5`, `2`, `1`, ``},
+		`5`: {`4`, `7`, `8`, `9`, `6`, `3`, `2`, `1`},
+		`6`: {`5`, `8`, `9`, `-`, `+`, ``, `3`, `2`},
+		`7`: {``, ``, ``, `=`, `8`, `5`, `4`, ``},
+		`8`: {`7`, ``, `=`, `/`, `9`, `6`, `5`, `4`},
+		`9`: {`8`, `=`, `/`, `*`, `-`, `+`, `6`, `5`},
+		`=`: {``, ``, ``, ``, `/`, `9`, `8`, `7`},
+	}
 }
 
-var adjacencyGraphDvorak = map[string][]string{
-	`$`: {`3#`, ``, ``, `5%`, `pP`, `.>`},
-	`(`: {`8*`, ``, ``, `0)`, `rR`, `cC`},
-	`,`: {`'"`, `2@`, `3#`, `.>`, `oO`, `aA`},
-	`0`: {`9(`, ``, ``, `[{`, `lL`, `rR`},
-	`4`: {`3#`, ``, ``, `5%`, `pP`, `.>`},
-	`8`: {`7&`, ``, ``, `9(`, `cC`, `gG`},
-	`<`: {`'"`, `2@`, `3#`, `.>`, `oO`, `aA`},
-	`@`: {`1!`, ``, ``, `3#`, `,<`, `'"`},
-	`D`: {`iI`, `fF`, `gG`, `hH`, `bB`, `xX`},
-	`H`: {`dD`, `gG`, `cC`, `tT`, `mM`, `bB`},
-	`L`: {`rR`, `0)`, `[{`, `/?`, `sS`, `nN`},
-	`P`: {`.>`, `4$`, `5%`, `yY`, `uU`, `eE`},
-	`T`: {`hH`, `cC`, `rR`, `nN`, `wW`, `mM`},
-	`X`: {`kK`, `iI`, `dD`, `bB`, ``, ``},
-	`\`: {`=+`, ``, ``, ``, ``, ``},
-	"`": {``, ``, ``, `1!`, ``, ``},
-	`d`: {`iI`, `fF`, `gG`, `hH`, `bB`, `xX`},
-	`h`: {`dD`, `gG`, `cC`, `tT`, `mM`, `bB`},
-	`l`: {`rR`, `0)`, `[{`, `/?`, `sS`, `nN`},
-	`p`: {`.>`, `4$`, `5%`, `yY`, `uU`, `eE`},
-	`t`: {`hH`, `cC`, `rR`, `nN`, `wW`, `mM`},
-	`x`: {`kK`, `iI`, `dD`, `bB`, ``, ``},
-	`|`: {`=+`, ``, ``, ``, ``, ``},
-	`#`: {`2@`, ``, ``, `4$`, `.>`, `,<`},
-	`'`: {``, `1!`, `2@`, `,<`, `aA`, ``},
-	`+`: {`/?`, `]}`, ``, `\|`, ``, `-_`},
-	`/`: {`lL`, `[{`, `]}`, `=+`, `-_`, `sS`},
-	`3`: {`2@`, ``, ``, `4$`, `.>`, `,<`},
-	`7`: {`6^`, ``, ``, `8*`, `gG`, `fF`},
-	`;`: {``, `aA`, `oO`, `qQ`, ``, ``},
-	`?`: {`lL`, `[{`, `]}`, `=+`, `-_`, `sS`},
-	`C`: {`gG`, `8*`, `9(`, `rR`, `tT`, `hH`},
-	`G`: {`fF`, `7&`, `8*`, `cC`, `hH`, `dD`},
-	`K`: {`jJ`, `uU`, `iI`, `xX`, ``, ``},
-	`O`: {`aA`, `,<`, `.>`, `eE`, `qQ`, `;:`},
-	`S`: {`nN`, `lL`, `/?`, `-_`, `zZ`, `vV`},
-	`W`: {`mM`, `tT`, `nN`, `vV`, ``, ``},
-	`[`: {`0)`, ``, ``, `]}`, `/?`, `lL`},
-	`_`: {`sS`, `/?`, `=+`, ``, ``, `zZ`},
-	`c`: {`gG`, `8*`, `9(`, `rR`, `tT`, `hH`},
-	`g`: {`fF`, `7&`, `8*`, `cC`, `hH`, `dD`},
-	`k`: {`jJ`, `uU`, `iI`, `xX`, ``, ``},
-	`o`: {`aA`, `,<`, `.>`, `eE`, `qQ`, `;:`},
-	`s`: {`nN`, `lL`, `/?`, `-_`, `zZ`, `vV`},
-	`w`: {`mM`, `tT`, `nN`, `vV`, ``, ``},
-	`{`: {`0)`, ``, ``, `]}`, `/?`, `lL`},
-	`"`: {``, `1!`, `2@`, `,<`, `aA`, ``},
-	`&`: {`6^`, ``, ``, `8*`, `gG`, `fF`},
-	`*`: {`7&`, ``, ``, `9(`, `cC`, `gG`},
-	`.`: {`,<`, `3#`, `4$`, `pP`, `eE`, `oO`},
-	`2`: {`1!`, ``, ``, `3#`, `,<`, `'"`},
-	`6`: {`5%`, ``, ``, `7&`, `fF`, `yY`},
-	`:`: {``, `aA`, `oO`, `qQ`, ``, ``},
-	`>`: {`,<`, `3#`, `4$`, `pP`, `eE`, `oO`},
-	`B`: {`xX`, `dD`, `hH`, `mM`, ``, ``},
-	`F`: {`yY`, `6^`, `7&`, `gG`, `dD`, `iI`},
-	`J`: {`qQ`, `eE`, `uU`, `kK`, ``, ``},
-	`N`: {`tT`, `rR`, `lL`, `sS`, `vV`, `wW`},
-	`R`: {`cC`, `9(`, `0)`, `lL`, `nN`, `tT`},
-	`V`: {`wW`, `nN`, `sS`, `zZ`, ``, ``},
-	`Z`: {`vV`, `sS`, `-_`, ``, ``, ``},
-	`^`: {`5%`, ``, ``, `7&`, `fF`, `yY`},
-	`b`: {`xX`, `dD`, `hH`, `mM`, ``, ``},
-	`f`: {`yY`, `6^`, `7&`, `gG`, `dD`, `iI`},
-	`j`: {`qQ`, `eE`, `uU`, `kK`, ``, ``},
-	`n`: {`tT`, `rR`, `lL`, `sS`, `vV`, `wW`},
-	`r`: {`cC`, `9(`, `0)`, `lL`, `nN`, `tT`},
-	`v`: {`wW`, `nN`, `sS`, `zZ`, ``, ``},
-	`z`: {`vV`, `sS`, `-_`, ``, ``, ``},
-	`~`: {``, ``, ``, `1!`, ``, ``},
-	`!`: {"`~", ``, ``, `2@`, `'"`, ``},
-	`%`: {`4$`, ``, ``, `6^`, `yY`, `pP`},
-	`)`: {`9(`, ``, ``, `[{`, `lL`, `rR`},
-	`-`: {`sS`, `/?`, `=+`, ``, ``, `zZ`},
-	`1`: {"`~", ``, ``, `2@`, `'"`, ``},
-	`5`: {`4$`, ``, ``, `6^`, `yY`, `pP`},
-	`9`: {`8*`, ``, ``, `0)`, `rR`, `cC`},
-	`=`: {`/?`, `]}`, ``, `\|`, ``, `-_`},
-	`A`: {``, `'"`, `,<`, `oO`, `;:`, ``},
-	`E`: {`oO`, `.>`, `pP`, `uU`, `jJ`, `qQ`},
-	`I`: {`uU`, `yY`, `fF`, `dD`, `xX`, `kK`},
-	`M`: {`bB`, `hH`, `tT`, `wW`, ``, ``},
-	`Q`: {`;:`, `oO`, `eE`, `jJ`, ``, ``},
-	`U`: {`eE`, `pP`, `yY`, `iI`, `kK`, `jJ`},
-	`Y`: {`pP`, `5%`, `6^`, `fF`, `iI`, `uU`},
-	`]`: {`[{`, ``, ``, ``, `=+`, `/?`},
-	`a`: {``, `'"`, `,<`, `oO`, `;:`, ``},
-	`e`: {`oO`, `.>`, `pP`, `uU`, `jJ`, `qQ`},
-	`i`: {`uU`, `yY`, `fF`, `dD`, `xX`, `kK`},
-	`m`: {`bB`, `hH`, `tT`, `wW`, ``, ``},
-	`q`: {`;:`, `oO`, `eE`, `jJ`, ``, ``},
-	`u`: {`eE`, `pP`, `yY`, `iI`, `kK`, `jJ`},
-	`y`: {`pP`, `5%`, `6^`, `fF`, `iI`, `uU`},
-	`}`: {`[{`, ``, ``, ``, `=+`, `/?`},
+func adjacencyGraphQwerty() map[string][]string {
+	return map[string][]string{
+		`!`: {"`~", ``, ``, `2@`, `qQ`, ``},
+		`"`: {`;:`, `[{`, `]}`, ``, ``, `/?`},
+		`#`: {`2@`, ``, ``, `4$`, `eE`, `wW`},
+		`$`: {`3#`, ``, ``, `5%`, `rR`, `eE`},
+		`%`: {`4$`, ``, ``, `6^`, `tT`, `rR`},
+		`&`: {`6^`, ``, ``, `8*`, `uU`, `yY`},
+		`'`: {`;:`, `[{`, `]}`, ``, ``, `/?`},
+		`(`: {`8*`, ``, ``, `0)`, `oO`, `iI`},
+		`)`: {`9(`, ``, ``, `-_`, `pP`, `oO`},
+		`*`: {`7&`, ``, ``, `9(`, `iI`, `uU`},
+		`+`: {`-_`, ``, ``, ``, `]}`, `[{`},
+		`,`: {`mM`, `kK`, `lL`, `.>`, ``, ``},
+		`-`: {`0)`, ``, ``, `=+`, `[{`, `pP`},
+		`.`: {`,<`, `lL`, `;:`, `/?`, ``, ``},
+		`/`: {`.>`, `;:`, `'"`, ``, ``, ``},
+		`0`: {`9(`, ``, ``, `-_`, `pP`, `oO`},
+		`1`: {"`~", ``, ``, `2@`, `qQ`, ``},
+		`2`: {`1!`, ``, ``, `3#`, `wW`, `qQ`},
+		`3`: {`2@`, ``, ``, `4$`, `eE`, `wW`},
+		`4`: {`3#`, ``, ``, `5%`, `rR`, `eE`},
+		`5`: {`4$`, ``, ``, `6^`, `tT`, `rR`},
+		`6`: {`5%`, ``, ``, `7&`, `yY`, `tT`},
+		`7`: {`6^`, ``, ``, `8*`, `uU`, `yY`},
+		`8`: {`7&`, ``, ``, `9(`, `iI`, `uU`},
+		`9`: {`8*`, ``, ``, `0)`, `oO`, `iI`},
+		`:`: {`lL`, `pP`, `[{`, `'"`, `/?`, `.>`},
+		`;`: {`lL`, `pP`, `[{`, `'"`, `/?`, `.>`},
+		`<`: {`mM`, `kK`, `lL`, `.>`, ``, ``},
+		`=`: {`-_`, ``, ``, ``, `]}`, `[{`},
+		`>`: {`,<`, `lL`, `;:`, `/?`, ``, ``},
+		`?`: {`.>`, `;:`, `'"`, ``, ``, ``},
+		`@`: {`1!`, ``, ``, `3#`, `wW`, `qQ`},
+		`A`: {``, `qQ`, `wW`, `sS`, `zZ`, ``},
+		`B`: {`vV`, `gG`, `hH`, `nN`, ``, ``},
+		`C`: {`xX`, `dD`, `fF`, `vV`, ``, ``},
+		`D`: {`sS`, `eE`, `rR`, `fF`, `cC`, `xX`},
+		`E`: {`wW`, `3#`, `4$`, `rR`, `dD`, `sS`},
+		`F`: {`dD`, `rR`, `tT`, `gG`, `vV`, `cC`},
+		`G`: {`fF`, `tT`, `yY`, `hH`, `bB`, `vV`},
+		`H`: {`gG`, `yY`, `uU`, `jJ`, `nN`, `bB`},
+		`I`: {`uU`, `8*`, `9(`, `oO`, `kK`, `jJ`},
+		`J`: {`hH`, `uU`, `iI`, `kK`, `mM`, `nN`},
+		`K`: {`jJ`, `iI`, `oO`, `lL`, `,<`, `mM`},
+		`L`: {`kK`, `oO`, `pP`, `;:`, `.>`, `,<`},
+		`M`: {`nN`, `jJ`, `kK`, `,<`, ``, ``},
+		`N`: {`bB`, `hH`, `jJ`, `mM`, ``, ``},
+		`O`: {`iI`, `9(`, `0)`, `pP`, `lL`, `kK`},
+		`P`: {`oO`, `0)`, `-_`, `[{`, `;:`, `lL`},
+		`Q`: {``, `1!`, `2@`, `wW`, `aA`, ``},
+		`R`: {`eE`, `4$`, `5%`, `tT`, `fF`, `dD`},
+		`S`: {`aA`, `wW`, `eE`, `dD`, `xX`, `zZ`},
+		`T`: {`rR`, `5%`, `6^`, `yY`, `gG`, `fF`},
+		`U`: {`yY`, `7&`, `8*`, `iI`, `jJ`, `hH`},
+		`V`: {`cC`, `fF`, `gG`, `bB`, ``, ``},
+		`W`: {`qQ`, `2@`, `3#`, `eE`, `sS`, `aA`},
+		`X`: {`zZ`, `sS`, `dD`, `cC`, ``, ``},
+		`Y`: {`tT`, `6^`, `7&`, `uU`, `hH`, `gG`},
+		`Z`: {``, `aA`, `sS`, `xX`, ``, ``},
+		`[`: {`pP`, `-_`, `=+`, `]}`, `'"`, `;:`},
+		`\`: {`]}`, ``, ``, ``, ``, ``},
+		`]`: {`[{`, `=+`, ``, `\|`, ``, `'"`},
+		`^`: {`5%`, ``, ``, `7&`, `yY`, `tT`},
+		`_`: {`0)`, ``, ``, `=+`, `[{`, `pP`},
+		"`": {``, ``, ``, `1!`, ``, ``},
+		`a`: {``, `qQ`, `wW`, `sS`, `zZ`, ``},
+		`b`: {`vV`, `gG`, `hH`, `nN`, ``, ``},
+		`c`: {`xX`, `dD`, `fF`, `vV`, ``, ``},
+		`d`: {`sS`, `eE`, `rR`, `fF`, `cC`, `xX`},
+		`e`: {`wW`, `3#`, `4$`, `rR`, `dD`, `sS`},
+		`f`: {`dD`, `rR`, `tT`, `gG`, `vV`, `cC`},
+		`g`: {`fF`, `tT`, `yY`, `hH`, `bB`, `vV`},
+		`h`: {`gG`, `yY`, `uU`, `jJ`, `nN`, `bB`},
+		`i`: {`uU`, `8*`, `9(`, `oO`, `kK`, `jJ`},
+		`j`: {`hH`, `uU`, `iI`, `kK`, `mM`, `nN`},
+		`k`: {`jJ`, `iI`, `oO`, `lL`, `,<`, `mM`},
+		`l`: {`kK`, `oO`, `pP`, `;:`, `.>`, `,<`},
+		`m`: {`nN`, `jJ`, `kK`, `,<`, ``, ``},
+		`n`: {`bB`, `hH`, `jJ`, `mM`, ``, ``},
+		`o`: {`iI`, `9(`, `0)`, `pP`, `lL`, `kK`},
+		`p`: {`oO`, `0)`, `-_`, `[{`, `;:`, `lL`},
+		`q`: {``, `1!`, `2@`, `wW`, `aA`, ``},
+		`r`: {`eE`, `4$`, `5%`, `tT`, `fF`, `dD`},
+		`s`: {`aA`, `wW`, `eE`, `dD`, `xX`, `zZ`},
+		`t`: {`rR`, `5%`, `6^`, `yY`, `gG`, `fF`},
+		`u`: {`yY`, `7&`, `8*`, `iI`, `jJ`, `hH`},
+		`v`: {`cC`, `fF`, `gG`, `bB`, ``, ``},
+		`w`: {`qQ`, `2@`, `3#`, `eE`, `sS`, `aA`},
+		`x`: {`zZ`, `sS`, `dD`, `cC`, ``, ``},
+		`y`: {`tT`, `6^`, `7&`, `uU`, `hH`, `gG`},
+		`z`: {``, `aA`, `sS`, `xX`, ``, ``},
+		`{`: {`pP`, `-_`, `=+`, `]}`, `'"`, `;:`},
+		`|`: {`]}`, ``, ``, ``, ``, ``},
+		`}`: {`[{`, `=+`, ``, `\|`, ``, `'"`},
+		`~`: {``, ``, ``, `1!`, ``, ``},
+	}
 }
 
-var adjacencyGraphKeypad = map[string][]string{
-	`+`: {`9`, `*`, `-`, ``, ``, ``, ``, `6`},
-	`*`: {`/`, ``, ``, ``, `-`, `+`, `9`, `8`},
-	`-`: {`*`, ``, ``, ``, ``, ``, `+`, `9`},
-	`/`: {``, ``, ``, ``, `*`, `9`, `8`, `7`},
-	`.`: {`0`, `2`, `3`, ``, ``, ``, ``, ``},
-	`1`: {``, ``, `4`, `5`, `2`, `0`, ``, ``},
-	`0`: {``, `1`, `2`, `3`, `.`, ``, ``, ``},
-	`3`: {`2`, `5`, `6`, ``, ``, ``, `.`, `0`},
-	`2`: {`1`, `4`, `5`, `6`, `3`, `.`, `0`, ``},
-	`5`: {`4`, `7`, `8`, `9`, `6`, `3`, `2`, `1`},
-	`4`: {``, ``, `7`, `8`, `5`, `2`, `1`, ``},
-	`7`: {``, ``, ``, `/`, `8`, `5`, `4`, ``},
-	`6`: {`5`, `8`, `9`, `+`, ``, ``, `3`, `2`},
-	`9`: {`8`, `/`, `*`, `-`, `+`, ``, `6`, `5`},
-	`8`: {`7`, ``, `/`, `*`, `9`, `6`, `5`, `4`},
+func adjacencyGraphQwertz() map[string][]string {
+	return map[string][]string{
+		`!`: {`^°`, ``, ``, `2"²`, `qQ@`, ``},
+		`"`: {`1!`, ``, ``, `3§³`, `wW`, `qQ@`},
+		`#`: {`äÄ`, `+*~`, ``, ``, ``, ``},
+		`$`: {`3§³`, ``, ``, `5%`, `rR`, `eE€`},
+		`%`: {`4$`, ``, ``, `6&`, `tT`, `rR`},
+		`&`: {`5%`, ``, ``, `7/{`, `zZ`, `tT`},
+		`'`: {`äÄ`, `+*~`, ``, ``, ``, ``},
+		`(`: {`7/{`, ``, ``, `9)]`, `iI`, `uU`},
+		`)`: {`8([`, ``, ``, `0=}`, `oO`, `iI`},
+		`*`: {`üÜ`, "´`", ``, ``, `#'`, `äÄ`},
+		`+`: {`üÜ`, "´`", ``, ``, `#'`, `äÄ`},
+		`,`: {`mMµ`, `kK`, `lL`, `.:`, ``, ``},
+		`-`: {`.:`, `öÖ`, `äÄ`, ``, ``, ``},
+		`.`: {`,;`, `lL`, `öÖ`, `-_`, ``, ``},
+		`/`: {`6&`, ``, ``, `8([`, `uU`, `zZ`},
+		`0`: {`9)]`, ``, ``, `ß?\`, `pP`, `oO`},
+		`1`: {`^°`, ``, ``, `2"²`, `qQ@`, ``},
+		`2`: {`1!`, ``, ``, `3§³`, `wW`, `qQ@`},
+		`3`: {`2"²`, ``, ``, `4$`, `eE€`, `wW`},
+		`4`: {`3§³`, ``, ``, `5%`, `rR`, `eE€`},
+		`5`: {`4$`, ``, ``, `6&`, `tT`, `rR`},
+		`6`: {`5%`, ``, ``, `7/{`, `zZ`, `tT`},
+		`7`: {`6&`, ``, ``, `8([`, `uU`, `zZ`},
+		`8`: {`7/{`, ``, ``, `9)]`, `iI`, `uU`},
+		`9`: {`8([`, ``, ``, `0=}`, `oO`, `iI`},
+		`:`: {`,;`, `lL`, `öÖ`, `-_`, ``, ``},
+		`;`: {`mMµ`, `kK`, `lL`, `.:`, ``, ``},
+		`<`: {``, ``, `aA`, `yY`, ``, ``},
+		`=`: {`9)]`, ``, ``, `ß?\`, `pP`, `oO`},
+		`>`: {``, ``, `aA`, `yY`, ``, ``},
+		`?`: {`0=}`, ``, ``, "´`", `üÜ`, `pP`},
+		`@`: {``, `1!`, `2"²`, `wW`, `aA`, ``},
+		`A`: {``, `qQ@`, `wW`, `sS`, `yY`, `<>|`},
+		`B`: {`vV`, `gG`, `hH`, `nN`, ``, ``},
+		`C`: {`xX`, `dD`, `fF`, `vV`, ``, ``},
+		`D`: {`sS`, `eE€`, `rR`, `fF`, `cC`, `xX`},
+		`E`: {`wW`, `3§³`, `4$`, `rR`, `dD`, `sS`},
+		`F`: {`dD`, `rR`, `tT`, `gG`, `vV`, `cC`},
+		`G`: {`fF`, `tT`, `zZ`, `hH`, `bB`, `vV`},
+		`H`: {`gG`, `zZ`, `uU`, `jJ`, `nN`, `bB`},
+		`I`: {`uU`, `8([`, `9)]`, `oO`, `kK`, `jJ`},
+		`J`: {`hH`, `uU`, `iI`, `kK`, `mMµ`, `nN`},
+		`K`: {`jJ`, `iI`, `oO`, `lL`, `,;`, `mMµ`},
+		`L`: {`kK`, `oO`, `pP`, `öÖ`, `.:`, `,;`},
+		`M`: {`nN`, `jJ`, `kK`, `,;`, ``, ``},
+		`N`: {`bB`, `hH`, `jJ`, `mMµ`, ``, ``},
+		`O`: {`iI`, `9)]`, `0=}`, `pP`, `lL`, `kK`},
+		`P`: {`oO`, `0=}`, `ß?\`, `üÜ`, `öÖ`, `lL`},
+		`Q`: {``, `1!`, `2"²`, `wW`, `aA`, ``},
+		`R`: {`eE€`, `4$`, `5%`, `tT`, `fF`, `dD`},
+		`S`: {`aA`, `wW`, `eE€`, `dD`, `xX`, `yY`},
+		`T`: {`rR`, `5%`, `6&`, `zZ`, `gG`, `fF`},
+		`U`: {`zZ`, `7/{`, `8([`, `iI`, `jJ`, `hH`},
+		`V`: {`cC`, `fF`, `gG`, `bB`, ``, ``},
+		`W`: {`qQ@`, `2"²`, `3§³`, `eE€`, `sS`, `aA`},
+		`X`: {`yY`, `sS`, `dD`, `cC`, ``, ``},
+		`Y`: {`<>|`, `aA`, `sS`, `xX`, ``, ``},
+		`Z`: {`tT`, `6&`, `7/{`, `uU`, `hH`, `gG`},
+		`[`: {`7/{`, ``, ``, `9)]`, `iI`, `uU`},
+		`\`: {`0=}`, ``, ``, "´`", `üÜ`, `pP`},
+		`]`: {`8([`, ``, ``, `0=}`, `oO`, `iI`},
+		`^`: {``, ``, ``, `1!`, ``, ``},
+		`_`: {`.:`, `öÖ`, `äÄ`, ``, ``, ``},
+		"`": {`ß?\`, ``, ``, ``, `+*~`, `üÜ`},
+		`a`: {``, `qQ@`, `wW`, `sS`, `yY`, `<>|`},
+		`b`: {`vV`, `gG`, `hH`, `nN`, ``, ``},
+		`c`: {`xX`, `dD`, `fF`, `vV`, ``, ``},
+		`d`: {`sS`, `eE€`, `rR`, `fF`, `cC`, `xX`},
+		`e`: {`wW`, `3§³`, `4$`, `rR`, `dD`, `sS`},
+		`f`: {`dD`, `rR`, `tT`, `gG`, `vV`, `cC`},
+		`g`: {`fF`, `tT`, `zZ`, `hH`, `bB`, `vV`},
+		`h`: {`gG`, `zZ`, `uU`, `jJ`, `nN`, `bB`},
+		`i`: {`uU`, `8([`, `9)]`, `oO`, `kK`, `jJ`},
+		`j`: {`hH`, `uU`, `iI`, `kK`, `mMµ`, `nN`},
+		`k`: {`jJ`, `iI`, `oO`, `lL`, `,;`, `mMµ`},
+		`l`: {`kK`, `oO`, `pP`, `öÖ`, `.:`, `,;`},
+		`m`: {`nN`, `jJ`, `kK`, `,;`, ``, ``},
+		`n`: {`bB`, `hH`, `jJ`, `mMµ`, ``, ``},
+		`o`: {`iI`, `9)]`, `0=}`, `pP`, `lL`, `kK`},
+		`p`: {`oO`, `0=}`, `ß?\`, `üÜ`, `öÖ`, `lL`},
+		`q`: {``, `1!`, `2"²`, `wW`, `aA`, ``},
+		`r`: {`eE€`, `4$`, `5%`, `tT`, `fF`, `dD`},
+		`s`: {`aA`, `wW`, `eE€`, `dD`, `xX`, `yY`},
+		`t`: {`rR`, `5%`, `6&`, `zZ`, `gG`, `fF`},
+		`u`: {`zZ`, `7/{`, `8([`, `iI`, `jJ`, `hH`},
+		`v`: {`cC`, `fF`, `gG`, `bB`, ``, ``},
+		`w`: {`qQ@`, `2"²`, `3§³`, `eE€`, `sS`, `aA`},
+		`x`: {`yY`, `sS`, `dD`, `cC`, ``, ``},
+		`y`: {`<>|`, `aA`, `sS`, `xX`, ``, ``},
+		`z`: {`tT`, `6&`, `7/{`, `uU`, `hH`, `gG`},
+		`{`: {`6&`, ``, ``, `8([`, `uU`, `zZ`},
+		`|`: {``, ``, `aA`, `yY`, ``, ``},
+		`}`: {`9)]`, ``, ``, `ß?\`, `pP`, `oO`},
+		`~`: {`üÜ`, "´`", ``, ``, `#'`, `äÄ`},
+		`§`: {`2"²`, ``, ``, `4$`, `eE€`, `wW`},
+		`°`: {``, ``, ``, `1!`, ``, ``},
+		`²`: {`1!`, ``, ``, `3§³`, `wW`, `qQ@`},
+		`³`: {`2"²`, ``, ``, `4$`, `eE€`, `wW`},
+		`´`: {`ß?\`, ``, ``, ``, `+*~`, `üÜ`},
+		`µ`: {`nN`, `jJ`, `kK`, `,;`, ``, ``},
+		`Ä`: {`öÖ`, `üÜ`, `+*~`, `#'`, ``, `-_`},
+		`Ö`: {`lL`, `pP`, `üÜ`, `äÄ`, `-_`, `.:`},
+		`Ü`: {`pP`, `ß?\`, "´`", `+*~`, `äÄ`, `öÖ`},
+		`ß`: {`0=}`, ``, ``, "´`", `üÜ`, `pP`},
+		`ä`: {`öÖ`, `üÜ`, `+*~`, `#'`, ``, `-_`},
+		`ö`: {`lL`, `pP`, `üÜ`, `äÄ`, `-_`, `.:`},
+		`ü`: {`pP`, `ß?\`, "´`", `+*~`, `äÄ`, `öÖ`},
+		`€`: {`wW`, `3§³`, `4$`, `rR`, `dD`, `sS`},
+	}
 }
 
-var adjacencyGraphMacKeypad = map[string][]string{
-	`+`: {`6`, `9`, `-`, ``, ``, ``, ``, `3`},
-	`*`: {`/`, ``, ``, ``, ``, ``, `-`, `9`},
-	`-`: {`9`, `/`, `*`, ``, ``, ``, `+`, `6`},
-	`/`: {`=`, ``, ``, ``, `*`, `-`, `9`, `8`},
-	`.`: {`0`, `2`, `3`, ``, ``, ``, ``, ``},
-	`1`: {``, ``, `4`, `5`, `2`, `0`, ``, ``},
-	`0`: {``, `1`, `2`, `3`, `.`, ``, ``, ``},
-	`3`: {`2`, `5`, `6`, `+`, ``, ``, `.`, `0`},
-	`2`: {`1`, `4`, `5`, `6`, `3`, `.`, `0`, ``},
-	`5`: {`4`, `7`, `8`, `9`, `6`, `3`, `2`, `1`},
-	`4`: {``, ``, `7`, `8`, `5`, `2`, `1`, ``},
-	`7`: {``, ``, ``, `=`, `8`, `5`, `4`, ``},
-	`6`: {`5`, `8`, `9`, `-`, `+`, ``, `3`, `2`},
-	`9`: {`8`, `=`, `/`, `*`, `-`, `+`, `6`, `5`},
-	`8`: {`7`, ``, `=`, `/`, `9`, `6`, `5`, `4`},
-	`=`: {``, ``, ``, ``, `/`, `9`, `8`, `7`},
+func adjacencyGraphWorkman() map[string][]string {
+	return map[string][]string{
+		`!`: {"`~", ``, ``, `2@`, `qQ`, ``},
+		`"`: {`iI`, `[{`, `]}`, ``, ``, `/?`},
+		`#`: {`2@`, ``, ``, `4$`, `rR`, `dD`},
+		`$`: {`3#`, ``, ``, `5%`, `wW`, `rR`},
+		`%`: {`4$`, ``, ``, `6^`, `bB`, `wW`},
+		`&`: {`6^`, ``, ``, `8*`, `fF`, `jJ`},
+		`'`: {`iI`, `[{`, `]}`, ``, ``, `/?`},
+		`(`: {`8*`, ``, ``, `0)`, `pP`, `uU`},
+		`)`: {`9(`, ``, ``, `-_`, `;:`, `pP`},
+		`*`: {`7&`, ``, ``, `9(`, `uU`, `fF`},
+		`+`: {`-_`, ``, ``, ``, `]}`, `[{`},
+		`,`: {`lL`, `eE`, `oO`, `.>`, ``, ``},
+		`-`: {`0)`, ``, ``, `=+`, `[{`, `;:`},
+		`.`: {`,<`, `oO`, `iI`, `/?`, ``, ``},
+		`/`: {`.>`, `iI`, `'"`, ``, ``, ``},
+		`0`: {`9(`, ``, ``, `-_`, `;:`, `pP`},
+		`1`: {"`~", ``, ``, `2@`, `qQ`, ``},
+		`2`: {`1!`, ``, ``, `3#`, `dD`, `qQ`},
+		`3`: {`2@`, ``, ``, `4$`, `rR`, `dD`},
+		`4`: {`3#`, ``, ``, `5%`, `wW`, `rR`},
+		`5`: {`4$`, ``, ``, `6^`, `bB`, `wW`},
+		`6`: {`5%`, ``, ``, `7&`, `jJ`, `bB`},
+		`7`: {`6^`, ``, ``, `8*`, `fF`, `jJ`},
+		`8`: {`7&`, ``, ``, `9(`, `uU`, `fF`},
+		`9`: {`8*`, ``, ``, `0)`, `pP`, `uU`},
+		`:`: {`pP`, `0)`, `-_`, `[{`, `iI`, `oO`},
+		`;`: {`pP`, `0)`, `-_`, `[{`, `iI`, `oO`},
+		`<`: {`lL`, `eE`, `oO`, `.>`, ``, ``},
+		`=`: {`-_`, ``, ``, ``, `]}`, `[{`},
+		`>`: {`,<`, `oO`, `iI`, `/?`, ``, ``},
+		`?`: {`.>`, `iI`, `'"`, ``, ``, ``},
+		`@`: {`1!`, ``, ``, `3#`, `dD`, `qQ`},
+		`A`: {``, `qQ`, `dD`, `sS`, `zZ`, ``},
+		`B`: {`wW`, `5%`, `6^`, `jJ`, `gG`, `tT`},
+		`C`: {`mM`, `tT`, `gG`, `vV`, ``, ``},
+		`D`: {`qQ`, `2@`, `3#`, `rR`, `sS`, `aA`},
+		`E`: {`nN`, `uU`, `pP`, `oO`, `,<`, `lL`},
+		`F`: {`jJ`, `7&`, `8*`, `uU`, `nN`, `yY`},
+		`G`: {`tT`, `bB`, `jJ`, `yY`, `vV`, `cC`},
+		`H`: {`sS`, `rR`, `wW`, `tT`, `mM`, `xX`},
+		`I`: {`oO`, `;:`, `[{`, `'"`, `/?`, `.>`},
+		`J`: {`bB`, `6^`, `7&`, `fF`, `yY`, `gG`},
+		`K`: {`vV`, `yY`, `nN`, `lL`, ``, ``},
+		`L`: {`kK`, `nN`, `eE`, `,<`, ``, ``},
+		`M`: {`xX`, `hH`, `tT`, `cC`, ``, ``},
+		`N`: {`yY`, `fF`, `uU`, `eE`, `lL`, `kK`},
+		`O`: {`eE`, `pP`, `;:`, `iI`, `.>`, `,<`},
+		`P`: {`uU`, `9(`, `0)`, `;:`, `oO`, `eE`},
+		`Q`: {``, `1!`, `2@`, `dD`, `aA`, ``},
+		`R`: {`dD`, `3#`, `4$`, `wW`, `hH`, `sS`},
+		`S`: {`aA`, `dD`, `rR`, `hH`, `xX`, `zZ`},
+		`T`: {`hH`, `wW`, `bB`, `gG`, `cC`, `mM`},
+		`U`: {`fF`, `8*`, `9(`, `pP`, `eE`, `nN`},
+		`V`: {`cC`, `gG`, `yY`, `kK`, ``, ``},
+		`W`: {`rR`, `4$`, `5%`, `bB`, `tT`, `hH`},
+		`X`: {`zZ`, `sS`, `hH`, `mM`, ``, ``},
+		`Y`: {`gG`, `jJ`, `fF`, `nN`, `kK`, `vV`},
+		`Z`: {``, `aA`, `sS`, `xX`, ``, ``},
+		`[`: {`;:`, `-_`, `=+`, `]}`, `'"`, `iI`},
+		`\`: {`]}`, ``, ``, ``, ``, ``},
+		`]`: {`[{`, `=+`, ``, `\|`, ``, `'"`},
+		`^`: {`5%`, ``, ``, `7&`, `jJ`, `bB`},
+		`_`: {`0)`, ``, ``, `=+`, `[{`, `;:`},
+		"`": {``, ``, ``, `1!`, ``, ``},
+		`a`: {``, `qQ`, `dD`, `sS`, `zZ`, ``},
+		`b`: {`wW`, `5%`, `6^`, `jJ`, `gG`, `tT`},
+		`c`: {`mM`, `tT`, `gG`, `vV`, ``, ``},
+		`d`: {`qQ`, `2@`, `3#`, `rR`, `sS`, `aA`},
+		`e`: {`nN`, `uU`, `pP`, `oO`, `,<`, `lL`},
+		`f`: {`jJ`, `7&`, `8*`, `uU`, `nN`, `yY`},
+		`g`: {`tT`, `bB`, `jJ`, `yY`, `vV`, `cC`},
+		`h`: {`sS`, `rR`, `wW`, `tT`, `mM`, `xX`},
+		`i`: {`oO`, `;:`, `[{`, `'"`, `/?`, `.>`},
+		`j`: {`bB`, `6^`, `7&`, `fF`, `yY`, `gG`},
+		`k`: {`vV`, `yY`, `nN`, `lL`, ``, ``},
+		`l`: {`kK`, `nN`, `eE`, `,<`, ``, ``},
+		`m`: {`xX`, `hH`, `tT`, `cC`, ``, ``},
+		`n`: {`yY`, `fF`, `uU`, `eE`, `lL`, `kK`},
+		`o`: {`eE`, `pP`, `;:`, `iI`, `.>`, `,<`},
+		`p`: {`uU`, `9(`, `0)`, `;:`, `oO`, `eE`},
+		`q`: {``, `1!`, `2@`, `dD`, `aA`, ``},
+		`r`: {`dD`, `3#`, `4$`, `wW`, `hH`, `sS`},
+		`s`: {`aA`, `dD`, `rR`, `hH`, `xX`, `zZ`},
+		`t`: {`hH`, `wW`, `bB`, `gG`, `cC`, `mM`},
+		`u`: {`fF`, `8*`, `9(`, `pP`, `eE`, `nN`},
+		`v`: {`cC`, `gG`, `yY`, `kK`, ``, ``},
+		`w`: {`rR`, `4$`, `5%`, `bB`, `tT`, `hH`},
+		`x`: {`zZ`, `sS`, `hH`, `mM`, ``, ``},
+		`y`: {`gG`, `jJ`, `fF`, `nN`, `kK`, `vV`},
+		`z`: {``, `aA`, `sS`, `xX`, ``, ``},
+		`{`: {`;:`, `-_`, `=+`, `]}`, `'"`, `iI`},
+		`|`: {`]}`, ``, ``, ``, ``, ``},
+		`}`: {`[{`, `=+`, ``, `\|`, ``, `'"`},
+		`~`: {``, ``, ``, `1!`, ``, ``},
+	}
 }
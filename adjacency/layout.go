@@ -0,0 +1,140 @@
+package adjacency
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxLevels is the number of characters a key may hold: unshifted, shifted and AltGr.
+const MaxLevels = 3
+
+// ParseLayout parses the textual description of a keyboard layout into a Graph.
+//
+// Lines starting with # are comments. The other lines are directives:
+//
+//	name <name>          the name of the graph, required
+//	geometry <geometry>  slanted for keyboards whose rows are shifted right from the one
+//	                     above, where keys have 6 neighbours; aligned for keypads, where
+//	                     they have 8. It defaults to slanted
+//	row <offset> <keys>  the next row, starting offset keys right of the leftmost one
+//
+// Keys are separated by spaces. Each key lists its characters, from 1 to MaxLevels:
+// unshifted, then shifted, then AltGr. For example, the US QWERTY layout starts with:
+//
+//	name qwerty
+//	geometry slanted
+//	row 0 `~ 1! 2@ 3# 4$ 5% 6^ 7& 8* 9( 0) -_ =+
+//	row 1 qQ wW eE rR tT yY uU iI oO pP [{ ]} \|
+func ParseLayout(r io.Reader) (*Graph, error) {
+	var (
+		name    string
+		slanted = true
+		rows    [][]string
+		offsets []int
+	)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		switch fields[0] {
+		case "name":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("adjacency: line %d: want name <name>", line)
+			}
+			name = fields[1]
+		case "geometry":
+			if len(fields) != 2 || (fields[1] != "slanted" && fields[1] != "aligned") {
+				return nil, fmt.Errorf("adjacency: line %d: want geometry slanted or aligned", line)
+			}
+			slanted = fields[1] == "slanted"
+		case "row":
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("adjacency: line %d: want row <offset> <keys>", line)
+			}
+			offset, err := strconv.Atoi(fields[1])
+			if err != nil || offset < 0 {
+				return nil, fmt.Errorf("adjacency: line %d: invalid row offset %q", line, fields[1])
+			}
+			for _, key := range fields[2:] {
+				if n := utf8.RuneCountInString(key); n > MaxLevels || !utf8.ValidString(key) {
+					return nil, fmt.Errorf("adjacency: line %d: invalid key %q", line, key)
+				}
+			}
+			rows = append(rows, fields[2:])
+			offsets = append(offsets, offset)
+		default:
+			return nil, fmt.Errorf("adjacency: line %d: unknown directive %q", line, fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("adjacency: layout has no name")
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("adjacency: layout %s has no rows", name)
+	}
+	graph, err := buildGraph(rows, offsets, slanted)
+	if err != nil {
+		return nil, fmt.Errorf("adjacency: layout %s: %w", name, err)
+	}
+	return NewGraph(name, graph), nil
+}
+
+// LoadLayout parses the keyboard layout described in the file at path.
+func LoadLayout(path string) (*Graph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseLayout(f)
+}
+
+type position struct{ x, y int }
+
+// slantedNeighbours are the six neighbours of a key on a keyboard where each row is slanted
+// to the right from the last, clockwise from the key on the left. Only near-diagonal keys
+// are adjacent: g is adjacent to t, y, b and v, but not to r, u, n or c.
+var slantedNeighbours = []position{{-1, 0}, {0, -1}, {1, -1}, {1, 0}, {0, 1}, {-1, 1}}
+
+// alignedNeighbours are the eight neighbours of a key on a keypad, clockwise from the key on the left.
+var alignedNeighbours = []position{{-1, 0}, {-1, -1}, {0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}}
+
+// buildGraph maps each character of the keys to its neighbouring keys, in clockwise order.
+// Missing neighbours, at the edges, are empty strings so that all the lists have the same
+// length and a position in them always means the same direction.
+func buildGraph(rows [][]string, offsets []int, slanted bool) (map[string][]string, error) {
+	keys := make(map[position]string)
+	for y, row := range rows {
+		for i, key := range row {
+			keys[position{offsets[y] + i, y}] = key
+		}
+	}
+	neighbours := alignedNeighbours
+	if slanted {
+		neighbours = slantedNeighbours
+	}
+	graph := make(map[string][]string)
+	for p, key := range keys {
+		adjacents := make([]string, len(neighbours))
+		for i, d := range neighbours {
+			adjacents[i] = keys[position{p.x + d.x, p.y + d.y}]
+		}
+		for _, c := range key {
+			if _, ok := graph[string(c)]; ok {
+				return nil, fmt.Errorf("character %q on several keys", c)
+			}
+			graph[string(c)] = adjacents
+		}
+	}
+	return graph, nil
+}
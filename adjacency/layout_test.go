@@ -0,0 +1,93 @@
+package adjacency
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLayout(t *testing.T) {
+	g, err := ParseLayout(strings.NewReader(`
+# a keyboard with an AltGr level
+name test
+row 0 1! 2@ 3#
+row 1 qQ wW€ eE
+row 1 aA sS
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "test", g.Name)
+	assert.Equal(t, []string{"qQ", "3#", "", "eE", "sS", "aA"}, g.Graph["w"])
+	assert.Equal(t, g.Graph["w"], g.Graph["€"])
+	assert.Equal(t, []string{"wW€", "", "", "", "", "sS"}, g.Graph["E"])
+	assert.Equal(t, []string{"", "qQ", "wW€", "sS", "", ""}, g.Graph["a"])
+	assert.Len(t, g.Graph, 17)
+
+	g, err = ParseLayout(strings.NewReader(`
+name pad
+geometry aligned
+row 0 7 8 9
+row 0 4 5 6
+row 1 0
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4", "7", "8", "9", "6", "", "0", ""}, g.Graph["5"])
+	assert.Equal(t, float64(3+5+3+4+6+4+3)/7, g.AverageDegree)
+}
+
+func TestParseLayoutErrors(t *testing.T) {
+	for _, tt := range []struct {
+		layout string
+		err    string
+	}{
+		{"row 0 aA", "layout has no name"},
+		{"name empty", "layout empty has no rows"},
+		{"name a b\nrow 0 aA", "line 1: want name <name>"},
+		{"name g\ngeometry round\nrow 0 aA", "line 2: want geometry slanted or aligned"},
+		{"name r\nrow aA", "line 2: want row <offset> <keys>"},
+		{"name r\nrow -1 aA", `line 2: invalid row offset "-1"`},
+		{"name k\nrow 0 aAáÁ", `line 2: invalid key "aAáÁ"`},
+		{"name d\nrow 0 aA bA", `layout d: character 'A' on several keys`},
+		{"name u\nkeys aA", `line 2: unknown directive "keys"`},
+	} {
+		_, err := ParseLayout(strings.NewReader(tt.layout))
+		assert.EqualError(t, err, "adjacency: "+tt.err, tt.layout)
+	}
+}
+
+func TestBuiltinLayouts(t *testing.T) {
+	// graphs.go is up to date with the layouts directory
+	paths, err := filepath.Glob(filepath.Join("layouts", "*.txt"))
+	require.NoError(t, err)
+	require.Len(t, paths, len(builtinGraphs))
+	for _, path := range paths {
+		g, err := LoadLayout(path)
+		require.NoError(t, err, path)
+		assert.Equal(t, strings.TrimSuffix(filepath.Base(path), ".txt"), g.Name)
+		assert.Equal(t, Get(g.Name), g, path)
+	}
+
+	_, err = LoadLayout(filepath.Join("layouts", "missing.txt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRegister(t *testing.T) {
+	assert.Nil(t, Get("register_test"))
+	t.Cleanup(func() {
+		graphsMu.Lock()
+		defer graphsMu.Unlock()
+		delete(Graphs, "register_test")
+	})
+	g := NewGraph("register_test", map[string][]string{"a": {"b"}, "b": {"a", ""}})
+	Register(g)
+	assert.Same(t, g, Get("register_test"))
+	assert.Equal(t, 1.0, g.AverageDegree)
+
+	// the deprecated Graphs follows Register and holds the built-in graphs
+	Preload()
+	assert.Same(t, g, Graphs["register_test"])
+	assert.Same(t, Get("qwerty"), Graphs["qwerty"])
+}
@@ -0,0 +1,123 @@
+package adjacency
+
+import "sort"
+
+// LayoutSwitch maps the characters typed with the layout typed active to the characters
+// of the layout intended on the same keys, at the same level: with the qwerty and jcuken
+// layouts, g maps to п and G to П, so that gfhjkm is пароль typed with the wrong layout.
+// Characters on the same key in both layouts, like the digits, are left out.
+//
+// The keys are placed from the directions of their neighbours in the graphs, so both
+// graphs must have the same geometry. The layouts are aligned on the characters they
+// share, most of them having to be on the same key in both. LayoutSwitch returns nil when
+// the graphs can't be aligned.
+func LayoutSwitch(typed, intended *Graph) map[rune]rune {
+	from, to := keyPositions(typed), keyPositions(intended)
+	if from == nil || to == nil || len(directions(typed)) != len(directions(intended)) {
+		return nil
+	}
+
+	// the offset from the typed keys to the intended ones is the one most shared characters agree on
+	keyOf := make(map[rune]string)
+	for key := range to {
+		for _, c := range key {
+			keyOf[c] = key
+		}
+	}
+	votes := make(map[position]int)
+	for key, p := range from {
+		for _, c := range key {
+			if k, ok := keyOf[c]; ok {
+				q := to[k]
+				votes[position{q.x - p.x, q.y - p.y}]++
+			}
+		}
+	}
+	if len(votes) == 0 {
+		return nil
+	}
+	offsets := make([]position, 0, len(votes))
+	for d := range votes {
+		offsets = append(offsets, d)
+	}
+	sort.Slice(offsets, func(i, j int) bool {
+		a, b := offsets[i], offsets[j]
+		if votes[a] != votes[b] {
+			return votes[a] > votes[b]
+		}
+		if a.y != b.y {
+			return a.y < b.y
+		}
+		return a.x < b.x
+	})
+	d := offsets[0]
+
+	at := make(map[position]string, len(to))
+	for key, p := range to {
+		at[p] = key
+	}
+	chars := make(map[rune]rune)
+	for key, p := range from {
+		typedLevels, intendedLevels := []rune(key), []rune(at[position{p.x + d.x, p.y + d.y}])
+		for i := 0; i < len(typedLevels) && i < len(intendedLevels); i++ {
+			if typedLevels[i] != intendedLevels[i] {
+				chars[typedLevels[i]] = intendedLevels[i]
+			}
+		}
+	}
+	return chars
+}
+
+// directions returns the directions of the neighbours listed by g for each character, or
+// nil if g is neither slanted nor aligned.
+func directions(g *Graph) []position {
+	for _, adjacents := range g.Graph {
+		switch len(adjacents) {
+		case len(slantedNeighbours):
+			return slantedNeighbours
+		case len(alignedNeighbours):
+			return alignedNeighbours
+		}
+		return nil
+	}
+	return nil
+}
+
+// keyPositions returns the positions of the keys of g, relative to the first of them in
+// lexical order. The keys that can't be reached from it through their neighbours are
+// left out. keyPositions returns nil if g has no neighbouring keys.
+func keyPositions(g *Graph) map[string]position {
+	dirs := directions(g)
+	if dirs == nil {
+		return nil
+	}
+	var start string
+	for _, adjacents := range g.Graph {
+		for _, key := range adjacents {
+			if key != "" && (start == "" || key < start) {
+				start = key
+			}
+		}
+	}
+	if start == "" {
+		return nil
+	}
+	positions := map[string]position{start: {}}
+	queue := []string{start}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		p := positions[key]
+		for _, c := range key {
+			for i, n := range g.Graph[string(c)] {
+				if _, ok := positions[n]; n == "" || ok {
+					continue
+				}
+				positions[n] = position{p.x + dirs[i].x, p.y + dirs[i].y}
+				queue = append(queue, n)
+			}
+			break // every character of a key has the same neighbours
+		}
+	}
+	return positions
+}
@@ -0,0 +1,56 @@
+package adjacency
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayoutSwitch(t *testing.T) {
+	translate := func(chars map[rune]rune, s string) string {
+		return strings.Map(func(r rune) rune {
+			if c, ok := chars[r]; ok {
+				return c
+			}
+			return r
+		}, s)
+	}
+
+	jcuken := LayoutSwitch(Get("qwerty"), Get("jcuken"))
+	assert.Equal(t, "пароль", translate(jcuken, "gfhjkm"))
+	assert.Equal(t, "ПАРОЛЬ", translate(jcuken, "GFHJKM"))
+	assert.Equal(t, "ёхъэжбю.,", translate(jcuken, "`[]';,./?"))
+	assert.Equal(t, `1"№;:?`, translate(jcuken, "1@#$^&"))
+	// the characters on the same keys are left out
+	assert.NotContains(t, jcuken, '1')
+	assert.NotContains(t, jcuken, '\\')
+
+	// and back
+	qwerty := LayoutSwitch(Get("jcuken"), Get("qwerty"))
+	assert.Equal(t, "gfhjkm", translate(qwerty, "пароль"))
+
+	hebrew := LayoutSwitch(Get("qwerty"), Get("hebrew"))
+	assert.Equal(t, "שלום", translate(hebrew, "akuo"))
+	assert.NotContains(t, hebrew, 'A')
+
+	// rows shifted in both layouts are aligned on the shared characters
+	shifted, err := ParseLayout(strings.NewReader(`
+name shifted
+row 2 1! 2@ 3#
+row 3 фФ цЦ уУ
+`))
+	require.NoError(t, err)
+	small, err := ParseLayout(strings.NewReader(`
+name small
+row 0 1! 2@ 3#
+row 1 qQ wW eE
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[rune]rune{'q': 'ф', 'Q': 'Ф', 'w': 'ц', 'W': 'Ц', 'e': 'у', 'E': 'У'},
+		LayoutSwitch(small, shifted))
+
+	// a keypad has another geometry
+	assert.Nil(t, LayoutSwitch(Get("qwerty"), Get("keypad")))
+}
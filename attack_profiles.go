@@ -0,0 +1,117 @@
+package zxcvbn
+
+import (
+	"fmt"
+	"math"
+)
+
+// AttackProfile describes an attacker testing GuessesPerSecond guesses per second.
+// Crack times are reported under Name in Result.CrackTimesSeconds and Result.CrackTimesDisplay.
+type AttackProfile struct {
+	Name             string
+	GuessesPerSecond float64
+}
+
+// Scaled returns a copy of p for an attacker factor times as fast, e.g. using factor GPUs
+// instead of one.
+func (p AttackProfile) Scaled(name string, factor float64) AttackProfile {
+	return AttackProfile{Name: name, GuessesPerSecond: p.GuessesPerSecond * factor}
+}
+
+// The scenarios reported by upstream zxcvbn.
+var (
+	// OnlineThrottling100PerHour is an online attack on a service rate-limiting login attempts.
+	OnlineThrottling100PerHour = AttackProfile{"online_throttling_100_per_hour", 100.0 / 3600}
+	// OnlineNoThrottling10PerSecond is an online attack on a service without rate limiting.
+	OnlineNoThrottling10PerSecond = AttackProfile{"online_no_throttling_10_per_second", 10}
+	// OfflineSlowHashing1e4PerSecond is an offline attack on a salted, slow hash.
+	OfflineSlowHashing1e4PerSecond = AttackProfile{"offline_slow_hashing_1e4_per_second", 1e4}
+	// OfflineFastHashing1e10PerSecond is an offline attack on a fast hash using many cores.
+	OfflineFastHashing1e10PerSecond = AttackProfile{"offline_fast_hashing_1e10_per_second", 1e10}
+)
+
+// DefaultAttackProfiles are the profiles reported by every Estimator.
+var DefaultAttackProfiles = []AttackProfile{
+	OnlineThrottling100PerHour,
+	OnlineNoThrottling10PerSecond,
+	OfflineSlowHashing1e4PerSecond,
+	OfflineFastHashing1e10PerSecond,
+}
+
+// Offline attacks on common hash algorithms.
+//
+// Rates are rough hashcat figures for a single high-end consumer GPU (RTX 4090 class);
+// use AttackProfile.Scaled to model a larger rig.
+var (
+	UnsaltedMD5    = AttackProfile{"unsalted_md5", 164e9}
+	UnsaltedSHA1   = AttackProfile{"unsalted_sha1", 50e9}
+	UnsaltedSHA256 = AttackProfile{"unsalted_sha256", 22e9}
+	UnsaltedSHA512 = AttackProfile{"unsalted_sha512", 7.4e9}
+	NTLM           = AttackProfile{"ntlm", 288e9}
+)
+
+// Bcrypt returns the profile of an offline attack on bcrypt hashes with the given cost.
+// It panics unless cost is between 4 and 31, the costs bcrypt accepts.
+func Bcrypt(cost int) AttackProfile {
+	if cost < 4 || cost > 31 {
+		panic(fmt.Sprintf("zxcvbn: invalid bcrypt cost %d", cost))
+	}
+	// about 184k guesses/s at cost 5, each extra cost doubling the work
+	return AttackProfile{
+		Name:             fmt.Sprintf("bcrypt_cost_%d", cost),
+		GuessesPerSecond: 184e3 * math.Pow(2, float64(5-cost)),
+	}
+}
+
+// PBKDF2SHA256 returns the profile of an offline attack on PBKDF2-HMAC-SHA256 hashes
+// with the given number of iterations. It panics if iterations is not positive.
+func PBKDF2SHA256(iterations int) AttackProfile {
+	if iterations < 1 {
+		panic(fmt.Sprintf("zxcvbn: invalid PBKDF2 iterations %d", iterations))
+	}
+	// about 8.8M guesses/s at 999 iterations, the cost being linear in iterations
+	return AttackProfile{
+		Name:             fmt.Sprintf("pbkdf2_sha256_%d", iterations),
+		GuessesPerSecond: 8.8e6 * 999 / float64(iterations),
+	}
+}
+
+// PBKDF2SHA512 returns the profile of an offline attack on PBKDF2-HMAC-SHA512 hashes
+// with the given number of iterations. It panics if iterations is not positive.
+func PBKDF2SHA512(iterations int) AttackProfile {
+	if iterations < 1 {
+		panic(fmt.Sprintf("zxcvbn: invalid PBKDF2 iterations %d", iterations))
+	}
+	// about 3.1M guesses/s at 999 iterations, the cost being linear in iterations
+	return AttackProfile{
+		Name:             fmt.Sprintf("pbkdf2_sha512_%d", iterations),
+		GuessesPerSecond: 3.1e6 * 999 / float64(iterations),
+	}
+}
+
+// Scrypt returns the profile of an offline attack on scrypt hashes with parameters N, r and p.
+// It panics if a parameter is not positive.
+func Scrypt(n, r, p int) AttackProfile {
+	if n < 1 || r < 1 || p < 1 {
+		panic(fmt.Sprintf("zxcvbn: invalid scrypt parameters N=%d r=%d p=%d", n, r, p))
+	}
+	// about 7k guesses/s with N=16384, r=8, p=1, the cost being linear in N*r*p
+	return AttackProfile{
+		Name:             fmt.Sprintf("scrypt_n%d_r%d_p%d", n, r, p),
+		GuessesPerSecond: 7e3 * (16384 * 8) / (float64(n) * float64(r) * float64(p)),
+	}
+}
+
+// Argon2id returns the profile of an offline attack on argon2id hashes using memoryKiB
+// KiB of memory and the given number of passes. It panics if a parameter is not positive.
+func Argon2id(memoryKiB, passes int) AttackProfile {
+	if memoryKiB < 1 || passes < 1 {
+		panic(fmt.Sprintf("zxcvbn: invalid argon2id parameters m=%d t=%d", memoryKiB, passes))
+	}
+	// argon2 is bound by memory bandwidth: every pass reads and writes the whole memory,
+	// at about 1TB/s.
+	return AttackProfile{
+		Name:             fmt.Sprintf("argon2id_m%d_t%d", memoryKiB, passes),
+		GuessesPerSecond: 1e12 / (2 * 1024 * float64(memoryKiB) * float64(passes)),
+	}
+}
@@ -0,0 +1,75 @@
+package zxcvbn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttackProfileCatalog(t *testing.T) {
+	// every extra bcrypt cost halves the attack rate
+	assert.Equal(t, "bcrypt_cost_12", Bcrypt(12).Name)
+	assert.Equal(t, Bcrypt(10).GuessesPerSecond/4, Bcrypt(12).GuessesPerSecond)
+
+	// PBKDF2 cost is linear in the number of iterations
+	assert.Equal(t, "pbkdf2_sha256_600000", PBKDF2SHA256(600000).Name)
+	assert.InEpsilon(t, PBKDF2SHA256(1000).GuessesPerSecond/600, PBKDF2SHA256(600000).GuessesPerSecond, 1e-12)
+
+	// argon2id cost is linear in memory and passes
+	assert.Equal(t, "argon2id_m65536_t3", Argon2id(65536, 3).Name)
+	assert.InEpsilon(t, Argon2id(65536, 1).GuessesPerSecond/6, Argon2id(131072, 3).GuessesPerSecond, 1e-12)
+
+	assert.Equal(t, "scrypt_n16384_r8_p1", Scrypt(16384, 8, 1).Name)
+	assert.Greater(t, UnsaltedMD5.GuessesPerSecond, Bcrypt(12).GuessesPerSecond)
+
+	scaled := UnsaltedMD5.Scaled("unsalted_md5_8_gpus", 8)
+	assert.Equal(t, AttackProfile{"unsalted_md5_8_gpus", 8 * UnsaltedMD5.GuessesPerSecond}, scaled)
+
+	// parameters out of range are rejected
+	for _, cost := range []int{-1, 0, 3, 32} {
+		assert.Panics(t, func() { Bcrypt(cost) }, "cost %d", cost)
+	}
+	for _, iterations := range []int{-1, 0} {
+		assert.Panics(t, func() { PBKDF2SHA256(iterations) }, "iterations %d", iterations)
+		assert.Panics(t, func() { PBKDF2SHA512(iterations) }, "iterations %d", iterations)
+	}
+	assert.Panics(t, func() { Scrypt(0, 8, 1) })
+	assert.Panics(t, func() { Scrypt(16384, -8, 1) })
+	assert.Panics(t, func() { Scrypt(16384, 8, 0) })
+	assert.Panics(t, func() { Argon2id(0, 3) })
+	assert.Panics(t, func() { Argon2id(65536, -1) })
+	assert.NotPanics(t, func() {
+		Bcrypt(4)
+		Bcrypt(31)
+		PBKDF2SHA256(1)
+		Scrypt(1, 1, 1)
+		Argon2id(1, 1)
+	})
+}
+
+func TestWithAttackProfiles(t *testing.T) {
+	custom := AttackProfile{Name: "our_bcrypt", GuessesPerSecond: 100}
+	e := New(WithAttackProfiles(Bcrypt(12), custom))
+	result := e.PasswordStrength("correcthorse", nil)
+
+	assert.Len(t, result.CrackTimesSeconds, len(DefaultAttackProfiles)+2)
+	assert.Equal(t, result.Guesses/100, result.CrackTimesSeconds["our_bcrypt"])
+	assert.Equal(t, result.Guesses/Bcrypt(12).GuessesPerSecond, result.CrackTimesSeconds["bcrypt_cost_12"])
+	assert.Equal(t, displayTime(result.Guesses/100), result.CrackTimesDisplay["our_bcrypt"])
+
+	// profiles with the same name replace earlier ones
+	e = New(WithAttackProfiles(AttackProfile{OfflineFastHashing1e10PerSecond.Name, 1}))
+	result = e.PasswordStrength("correcthorse", nil)
+	assert.Len(t, result.CrackTimesSeconds, len(DefaultAttackProfiles))
+	assert.Equal(t, result.Guesses, result.CrackTimesSeconds[OfflineFastHashing1e10PerSecond.Name])
+
+	// the default estimator only reports the default profiles
+	assert.Len(t, PasswordStrength("correcthorse", nil).CrackTimesSeconds, len(DefaultAttackProfiles))
+
+	assert.Panics(t, func() { WithAttackProfiles(AttackProfile{Name: "broken"}) })
+	assert.Panics(t, func() { WithAttackProfiles(AttackProfile{Name: "broken", GuessesPerSecond: -1}) })
+	assert.Panics(t, func() { WithAttackProfiles(AttackProfile{Name: "broken", GuessesPerSecond: math.NaN()}) })
+	assert.Panics(t, func() { WithAttackProfiles(AttackProfile{Name: "broken", GuessesPerSecond: math.Inf(1)}) })
+	assert.Panics(t, func() { WithAttackProfiles(AttackProfile{GuessesPerSecond: 1}) })
+}
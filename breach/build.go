@@ -0,0 +1,343 @@
+package breach
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+const (
+	// partitionBits is the number of leading bits of the digests the entries are
+	// spilled by, the shards of a filter being made of one or more partitions.
+	partitionBits = maxShardBits
+	// minShardLen is the number of passwords below which the filter is not split further.
+	minShardLen = 1 << 16
+	// DefaultSpillSize is the number of passwords a Builder keeps in memory.
+	DefaultSpillSize = 1 << 22
+)
+
+// Builder adds breached passwords to a new Filter.
+//
+// The passwords are kept in memory up to SpillSize of them, then appended to temporary
+// files by leading bits of their digest, so that building a filter of hundreds of
+// millions of passwords only takes the memory of one of its shards, about 50 bytes per
+// password of a 256th of them. A Builder must be closed to remove its files.
+type Builder struct {
+	// SpillSize is the number of passwords kept in memory, DefaultSpillSize by default.
+	SpillSize int
+
+	slotBytes int
+	tiers     [Tiers]int
+	// partitions are the entries added since the last spill by partition: the leading
+	// keyBits of the digests, shifted left by tierBits, or the tier.
+	partitions [1 << partitionBits][]uint64
+	buffered   int
+	dir        string
+	err        error
+}
+
+// NewBuilder returns a Builder with the given false positive rate per lookup, rounded
+// down to a power of 2 fitting the slots in whole bytes: 1e-6 gives 2^-20 with 3-byte
+// slots, about 29.5 bits per password. Passwords looked
+// up as several tokens, like the breached matcher does, are reported as breached while
+// they are not with a rate of about the number of tokens times that.
+func NewBuilder(falsePositiveRate float64) *Builder {
+	if !(falsePositiveRate > 0 && falsePositiveRate < 1) {
+		panic(fmt.Sprintf("breach: invalid false positive rate %v", falsePositiveRate))
+	}
+	fingerprintBits := math.Ceil(-math.Log2(falsePositiveRate))
+	slotBytes := int(math.Ceil((fingerprintBits + tierBits) / 8))
+	if slotBytes > 8 {
+		slotBytes = 8
+	}
+	return &Builder{SpillSize: DefaultSpillSize, slotBytes: slotBytes}
+}
+
+// Add adds the password with the given SHA-1 digest, seen count times.
+func (b *Builder) Add(digest [sha1.Size]byte, count int) {
+	t := tierOf(count)
+	b.tiers[t]++
+	e := binary.BigEndian.Uint64(digest[:])&^(1<<tierBits-1) | uint64(t)
+	p := e >> (64 - partitionBits)
+	b.partitions[p] = append(b.partitions[p], e)
+	if b.buffered++; b.buffered >= b.SpillSize {
+		b.spill()
+	}
+}
+
+// AddPassword adds password, seen count times.
+func (b *Builder) AddPassword(password string, count int) {
+	b.Add(sha1.Sum([]byte(password)), count)
+}
+
+// spill appends the entries in memory to the files of their partitions. The first error
+// is kept for WriteTo, the entries being dropped anyway so that memory stays bounded.
+func (b *Builder) spill() {
+	if b.err == nil && b.dir == "" {
+		b.dir, b.err = os.MkdirTemp("", "breach-filter-")
+	}
+	for p, entries := range b.partitions {
+		if b.err == nil && len(entries) > 0 {
+			b.err = appendEntries(b.partitionPath(p), entries)
+		}
+		b.partitions[p] = entries[:0]
+	}
+	b.buffered = 0
+}
+
+func (b *Builder) partitionPath(p int) string {
+	return filepath.Join(b.dir, fmt.Sprintf("%03d", p))
+}
+
+func appendEntries(path string, entries []uint64) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	var buf [8]byte
+	for _, e := range entries {
+		binary.LittleEndian.PutUint64(buf[:], e)
+		w.Write(buf[:])
+	}
+	err = w.Flush()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// appendPartition appends the entries of partition p, spilled or in memory, to entries.
+func (b *Builder) appendPartition(entries []uint64, p int) ([]uint64, error) {
+	if b.dir != "" {
+		data, err := os.ReadFile(b.partitionPath(p))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return entries, err
+		}
+		for i := 0; i+8 <= len(data); i += 8 {
+			entries = append(entries, binary.LittleEndian.Uint64(data[i:]))
+		}
+	}
+	return append(entries, b.partitions[p]...), nil
+}
+
+// Filter returns the Filter of the passwords added so far. It panics if the passwords
+// spilled to temporary files can't be read back; WriteTo returns the error instead.
+func (b *Builder) Filter() *Filter {
+	var buf bytes.Buffer
+	if _, err := b.WriteTo(&buf); err != nil {
+		panic(err)
+	}
+	f, err := New(buf.Bytes())
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// WriteTo writes the encoding of the filter to w, building it one shard at a time.
+func (b *Builder) WriteTo(w io.Writer) (int64, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	n := 0
+	for _, c := range b.tiers {
+		n += c
+	}
+	shardBits := 0
+	for shardBits < maxShardBits && n>>(shardBits+1) >= minShardLen {
+		shardBits++
+	}
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	bw.WriteString(magic)
+	binary.Write(bw, binary.LittleEndian, uint32(b.slotBytes))
+	binary.Write(bw, binary.LittleEndian, uint32(shardBits))
+	binary.Write(bw, binary.LittleEndian, uint32(Tiers))
+	for _, c := range b.tiers {
+		binary.Write(bw, binary.LittleEndian, uint64(c))
+	}
+
+	partitionsPerShard := 1 << (partitionBits - shardBits)
+	var entries []uint64
+	slot := make([]byte, b.slotBytes)
+	for s := 0; s < 1<<shardBits; s++ {
+		entries = entries[:0]
+		for p := s * partitionsPerShard; p < (s+1)*partitionsPerShard; p++ {
+			var err error
+			if entries, err = b.appendPartition(entries, p); err != nil {
+				return cw.n, err
+			}
+		}
+		seed, blockLength, slots := buildShard(uniqueKeys(entries), 8*b.slotBytes-tierBits, uint64(s))
+		binary.Write(bw, binary.LittleEndian, seed)
+		binary.Write(bw, binary.LittleEndian, blockLength)
+		for _, v := range slots {
+			for k := range slot {
+				slot[k] = byte(v >> (8 * k))
+			}
+			bw.Write(slot)
+		}
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// Close removes the temporary files of b. The Builder must not be used afterwards.
+func (b *Builder) Close() error {
+	if b.dir == "" {
+		return nil
+	}
+	err := os.RemoveAll(b.dir)
+	b.dir = ""
+	return err
+}
+
+// uniqueKeys sorts entries and keeps one entry per key, the one of the most common tier.
+func uniqueKeys(entries []uint64) []uint64 {
+	sort.Slice(entries, func(i, j int) bool { return entries[i] < entries[j] })
+	unique := entries[:0]
+	for _, e := range entries {
+		if len(unique) > 0 && unique[len(unique)-1]>>tierBits == e>>tierBits {
+			unique[len(unique)-1] = e
+		} else {
+			unique = append(unique, e)
+		}
+	}
+	return unique
+}
+
+// peeled is an entry assigned to the slot it was the only entry of.
+type peeled struct {
+	entry uint64
+	slot  uint32
+}
+
+// buildShard returns the seed, the number of slots per third and the slots of the xor
+// filter of entries, whose keys are distinct. The slots are found by peeling: an entry
+// alone in one of its slots is set aside, which may leave other slots with a single
+// entry, until every entry is set aside, trying other seeds until it succeeds. The
+// entries are then assigned their slot in the reverse order.
+func buildShard(entries []uint64, fingerprintBits int, state uint64) (uint64, uint32, []uint64) {
+	blockLength := uint32((32 + math.Ceil(1.23*float64(len(entries))) + 2) / 3)
+	size := 3 * int(blockLength)
+	counts := make([]uint32, size)
+	xors := make([]uint64, size)
+	stack := make([]peeled, 0, len(entries))
+	var queue []uint32
+	var seed uint64
+	for {
+		seed = splitMix64(&state)
+		for i := range counts {
+			counts[i], xors[i] = 0, 0
+		}
+		for _, e := range entries {
+			for _, i := range slotIndexes(hash(e>>tierBits, seed), blockLength) {
+				counts[i]++
+				xors[i] ^= e
+			}
+		}
+		queue, stack = queue[:0], stack[:0]
+		for i, c := range counts {
+			if c == 1 {
+				queue = append(queue, uint32(i))
+			}
+		}
+		for len(queue) > 0 {
+			i := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			if counts[i] != 1 {
+				continue
+			}
+			e := xors[i]
+			stack = append(stack, peeled{entry: e, slot: i})
+			for _, j := range slotIndexes(hash(e>>tierBits, seed), blockLength) {
+				counts[j]--
+				xors[j] ^= e
+				if counts[j] == 1 {
+					queue = append(queue, j)
+				}
+			}
+		}
+		if len(stack) == len(entries) {
+			break
+		}
+	}
+
+	// every entry being peeled, xors is all zeros and is reused for the slots
+	slots := xors
+	for k := len(stack) - 1; k >= 0; k-- {
+		p := stack[k]
+		h := hash(p.entry>>tierBits, seed)
+		v := fingerprint(h, fingerprintBits)<<tierBits | p.entry&(1<<tierBits-1)
+		for _, j := range slotIndexes(h, blockLength) {
+			v ^= slots[j]
+		}
+		slots[p.slot] = v
+	}
+	return seed, blockLength, slots
+}
+
+func splitMix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// ReadHashes reads the SHA-1 digests listed in r and calls fn for each of them until it
+// returns an error. Each line holds a hexadecimal digest, optionally followed by a colon
+// and the number of times the password was seen, like in the Have I Been Pwned downloads.
+// The count defaults to 1.
+func ReadHashes(r io.Reader, fn func(digest [sha1.Size]byte, count int) error) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		hash, countText, hasCount := bytes.Cut(text, []byte(":"))
+		var digest [sha1.Size]byte
+		if len(hash) != 2*sha1.Size {
+			return fmt.Errorf("line %d: invalid SHA-1 digest %q", line, hash)
+		}
+		if _, err := hex.Decode(digest[:], hash); err != nil {
+			return fmt.Errorf("line %d: invalid SHA-1 digest: %w", line, err)
+		}
+		count := 1
+		if hasCount {
+			var err error
+			if count, err = strconv.Atoi(string(countText)); err != nil {
+				return fmt.Errorf("line %d: invalid count: %w", line, err)
+			}
+		}
+		if err := fn(digest, count); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
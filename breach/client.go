@@ -0,0 +1,120 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Checker reports how many times a password was seen in breaches.
+type Checker interface {
+	Check(ctx context.Context, password string) (count int, err error)
+}
+
+// PrefixLength is the number of hexadecimal characters of the SHA-1 digest of
+// a password sent to a range API.
+const PrefixLength = 5
+
+// Transport fetches the breached passwords whose uppercase hexadecimal SHA-1 digest
+// starts with prefix, one per line as the remaining characters of the digest, optionally
+// followed by a colon and the number of times the password was seen.
+type Transport interface {
+	Range(ctx context.Context, prefix string) (io.ReadCloser, error)
+}
+
+// DefaultRangeURL is the range API of Have I Been Pwned.
+const DefaultRangeURL = "https://api.pwnedpasswords.com/range/"
+
+// HTTPTransport is a Transport querying a range API over HTTP, following the
+// k-anonymity protocol of Have I Been Pwned: only the prefix leaves the machine.
+type HTTPTransport struct {
+	// URL is the URL the prefix is appended to. It defaults to DefaultRangeURL.
+	URL string
+	// Client is the HTTP client. It defaults to http.DefaultClient.
+	Client *http.Client
+	// UserAgent is sent when not empty.
+	UserAgent string
+	// Padding requests padded responses, hiding the prefix from observers of the
+	// response size. Padding entries have a count of 0.
+	Padding bool
+}
+
+// Range implements Transport.
+func (t *HTTPTransport) Range(ctx context.Context, prefix string) (io.ReadCloser, error) {
+	url := t.URL
+	if url == "" {
+		url = DefaultRangeURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	if t.UserAgent != "" {
+		req.Header.Set("User-Agent", t.UserAgent)
+	}
+	if t.Padding {
+		req.Header.Set("Add-Padding", "true")
+	}
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("breach: range %s: %s", prefix, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// Client is a Checker querying a range API through its Transport.
+// A Client is safe for concurrent use if its Transport is.
+type Client struct {
+	transport Transport
+}
+
+// NewClient returns a Client using t, or an HTTPTransport querying DefaultRangeURL if t is nil.
+func NewClient(t Transport) *Client {
+	if t == nil {
+		t = &HTTPTransport{}
+	}
+	return &Client{transport: t}
+}
+
+// Check implements Checker.
+func (c *Client) Check(ctx context.Context, password string) (int, error) {
+	digest := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(digest[:]))
+	prefix, suffix := hash[:PrefixLength], hash[PrefixLength:]
+	body, err := c.transport.Range(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	scanner := bufio.NewScanner(body)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		s, countText, hasCount := strings.Cut(text, ":")
+		if !strings.EqualFold(s, suffix) {
+			continue
+		}
+		if !hasCount {
+			return 1, nil
+		}
+		count, err := strconv.Atoi(countText)
+		if err != nil {
+			return 0, fmt.Errorf("breach: range %s: line %d: invalid count: %w", prefix, line, err)
+		}
+		return count, nil
+	}
+	return 0, scanner.Err()
+}
@@ -0,0 +1,95 @@
+package breach
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SHA-1 of "password": 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+const passwordRange = "003D68EB55068C33ACE09247EE4C639306B:3\r\n" +
+	"1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n" +
+	"D0000000000000000000000000000000000:0\r\n"
+
+func TestClient(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		if r.URL.Path != "/range/5BAA6" {
+			w.Write([]byte("0000000000000000000000000000000000A:1\r\n"))
+			return
+		}
+		w.Write([]byte(passwordRange))
+	}))
+	defer server.Close()
+
+	c := NewClient(&HTTPTransport{
+		URL:       server.URL + "/range/",
+		Client:    server.Client(),
+		UserAgent: "zxcvbn-test",
+		Padding:   true,
+	})
+	count, err := c.Check(context.Background(), "password")
+	require.NoError(t, err)
+	assert.Equal(t, 9545824, count)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "zxcvbn-test", requests[0].Header.Get("User-Agent"))
+	assert.Equal(t, "true", requests[0].Header.Get("Add-Padding"))
+
+	count, err = c.Check(context.Background(), "correct horse battery staple")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Len(t, requests, 2)
+	assert.Len(t, requests[1].URL.Path, len("/range/")+PrefixLength)
+}
+
+func TestClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	c := NewClient(&HTTPTransport{URL: server.URL + "/range/", Client: server.Client()})
+	_, err := c.Check(context.Background(), "password")
+	assert.ErrorContains(t, err, "429")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.Check(ctx, "password")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	c = NewClient(transportFunc(func(ctx context.Context, prefix string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("1E4C9B93F3F0682250B6CF8331B7EE68FD8:many\n")), nil
+	}))
+	_, err = c.Check(context.Background(), "password")
+	assert.ErrorContains(t, err, "line 1: invalid count")
+
+	failure := errors.New("offline")
+	c = NewClient(transportFunc(func(ctx context.Context, prefix string) (io.ReadCloser, error) {
+		return nil, failure
+	}))
+	_, err = c.Check(context.Background(), "password")
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestClientWithoutCounts(t *testing.T) {
+	c := NewClient(transportFunc(func(ctx context.Context, prefix string) (io.ReadCloser, error) {
+		assert.Equal(t, "5BAA6", prefix)
+		return io.NopCloser(strings.NewReader("1e4c9b93f3f0682250b6cf8331b7ee68fd8\n")), nil
+	}))
+	count, err := c.Check(context.Background(), "password")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+type transportFunc func(ctx context.Context, prefix string) (io.ReadCloser, error)
+
+func (f transportFunc) Range(ctx context.Context, prefix string) (io.ReadCloser, error) {
+	return f(ctx, prefix)
+}
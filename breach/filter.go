@@ -0,0 +1,244 @@
+// Package breach checks passwords against large sets of breached passwords,
+// identified by their SHA-1 digest like in the Have I Been Pwned corpus.
+//
+// The sets are stored in a Filter, an xor filter that answers offline, in constant time
+// and within a small fraction of the size of the corpus, at the cost of reporting a small
+// proportion of passwords as breached while they are not. Each password is hashed to
+// three slots of the filter, one in each third of it, whose xor is a fingerprint of the
+// password followed by its prevalence tier: a lookup reads the tier along with the
+// fingerprint, and a password not in the filter is taken for a breached one when the
+// xor of its slots happens to be its fingerprint, with a probability of
+// 2^-fingerprintBits. The filter has about 1.23 slots per password.
+//
+// Passwords are filed in tiers by prevalence, the number of times they were seen in
+// breaches rounded down to a power of 10, so that the rank of a breached password
+// among the most common ones can be estimated.
+//
+// Alternatively, a Client checks passwords online against a range API such as the one
+// of Have I Been Pwned, sending only the first characters of their digest.
+//
+// The filter is split in shards by the leading bits of the digests, each shard being an
+// xor filter of its own, so that it is built one shard at a time. Encoding, all
+// integers being little endian:
+//
+//	magic       "ZXB3"
+//	slotBytes   uint32, the size of a slot: fingerprintBits is 8*slotBytes - 4
+//	shardBits   uint32, the number of leading bits of the digests picking their shard
+//	tierCount   uint32, then for each tier: uint64 number of passwords
+//	shards      for each shard: uint64 hash seed, uint32 number of slots per third,
+//	            then the slots, each holding the fingerprint bits shifted left by 4 or the tier
+package breach
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/akara-io/zxcvbn/internal/mmap"
+)
+
+const magic = "ZXB3"
+
+// Tiers is the number of prevalence tiers: tier t holds the passwords seen
+// between 10^t and 10^(t+1) - 1 times, the last tier holding all the more common ones.
+const Tiers = 10
+
+const (
+	// tierBits is the number of low bits of a slot or an entry holding its tier.
+	tierBits = 4
+	// keyBits is the number of leading bits of the digests the filter is keyed by.
+	keyBits      = 64 - tierBits
+	maxShardBits = 8
+)
+
+// Hit is what a Filter tells about a breached password.
+type Hit struct {
+	// Prevalence is a lower bound of the number of times the password was seen, a power of 10.
+	Prevalence int
+	// Rank is an upper bound of the rank of the password by prevalence: the number of
+	// passwords of the filter in its tier or a more common one.
+	Rank int
+}
+
+// Filter is an xor filter of breached passwords. A Filter is safe for concurrent use.
+type Filter struct {
+	slotBytes int
+	shardBits int
+	tiers     [Tiers]int
+	shards    []shard
+	close     func() error
+}
+
+// shard is the xor filter of the passwords whose digest starts with its index.
+type shard struct {
+	seed        uint64
+	blockLength uint32
+	slots       []byte
+}
+
+// New returns the Filter encoded in data, which must not be modified afterwards.
+func New(data []byte) (*Filter, error) {
+	if len(data) < len(magic) || string(data[:len(magic)]) != magic {
+		return nil, errors.New("breach: invalid magic")
+	}
+	data = data[len(magic):]
+	if len(data) < 12+8*Tiers {
+		return nil, errors.New("breach: truncated header")
+	}
+	f := &Filter{
+		slotBytes: int(binary.LittleEndian.Uint32(data)),
+		shardBits: int(binary.LittleEndian.Uint32(data[4:])),
+	}
+	tierCount := int(binary.LittleEndian.Uint32(data[8:]))
+	if tierCount != Tiers || f.slotBytes < 1 || f.slotBytes > 8 || f.shardBits < 0 || f.shardBits > maxShardBits {
+		return nil, fmt.Errorf("breach: unsupported filter with %d tiers, %d-byte slots and %d shard bits",
+			tierCount, f.slotBytes, f.shardBits)
+	}
+	data = data[12:]
+	for t := range f.tiers {
+		f.tiers[t] = int(binary.LittleEndian.Uint64(data[8*t:]))
+	}
+	data = data[8*Tiers:]
+	f.shards = make([]shard, 1<<f.shardBits)
+	for i := range f.shards {
+		if len(data) < 12 {
+			return nil, errors.New("breach: truncated shard")
+		}
+		s := shard{
+			seed:        binary.LittleEndian.Uint64(data),
+			blockLength: binary.LittleEndian.Uint32(data[8:]),
+		}
+		size := 3 * uint64(s.blockLength) * uint64(f.slotBytes)
+		if s.blockLength == 0 || uint64(len(data)-12) < size {
+			return nil, errors.New("breach: truncated shard")
+		}
+		s.slots = data[12 : 12+size]
+		data = data[12+size:]
+		f.shards[i] = s
+	}
+	if len(data) != 0 {
+		return nil, errors.New("breach: invalid filter size")
+	}
+	return f, nil
+}
+
+// Open memory-maps the filter file at path. The Filter must be closed to unmap it.
+func Open(path string) (*Filter, error) {
+	data, close, err := mmap.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := New(data)
+	if err != nil {
+		close()
+		return nil, err
+	}
+	f.close = close
+	return f, nil
+}
+
+// Close releases the resources of a Filter returned by Open. The Filter must not be used afterwards.
+func (f *Filter) Close() error {
+	if f.close == nil {
+		return nil
+	}
+	err := f.close()
+	f.close = nil
+	return err
+}
+
+// Len returns the number of passwords added to f.
+func (f *Filter) Len() int {
+	n := 0
+	for _, c := range f.tiers {
+		n += c
+	}
+	return n
+}
+
+// Lookup reports whether the password with the given SHA-1 digest is in f.
+func (f *Filter) Lookup(digest [sha1.Size]byte) (Hit, bool) {
+	key := binary.BigEndian.Uint64(digest[:]) >> tierBits
+	s := &f.shards[key>>(keyBits-f.shardBits)]
+	h := hash(key, s.seed)
+	var v uint64
+	for _, i := range slotIndexes(h, s.blockLength) {
+		v ^= s.slot(i, f.slotBytes)
+	}
+	if v>>tierBits != fingerprint(h, 8*f.slotBytes-tierBits) {
+		return Hit{}, false
+	}
+	tier := int(v & (1<<tierBits - 1))
+	if tier >= Tiers {
+		tier = Tiers - 1
+	}
+	rank := 0
+	for t := tier; t < Tiers; t++ {
+		rank += f.tiers[t]
+	}
+	return Hit{Prevalence: prevalence(tier), Rank: rank}, true
+}
+
+// LookupPassword reports whether password is in f.
+func (f *Filter) LookupPassword(password string) (Hit, bool) {
+	return f.Lookup(sha1.Sum([]byte(password)))
+}
+
+func (s *shard) slot(i uint32, size int) uint64 {
+	var v uint64
+	b := s.slots[int(i)*size : (int(i)+1)*size]
+	for k := len(b) - 1; k >= 0; k-- {
+		v = v<<8 | uint64(b[k])
+	}
+	return v
+}
+
+// hash mixes key with seed, so that a shard failing to build with a seed can be built
+// with another one.
+func hash(key, seed uint64) uint64 {
+	h := key + seed
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// slotIndexes returns the slots of the password with hash h, one in each third of a
+// shard of 3*blockLength slots.
+func slotIndexes(h uint64, blockLength uint32) [3]uint32 {
+	return [3]uint32{
+		reduce(uint32(h), blockLength),
+		blockLength + reduce(uint32(bits.RotateLeft64(h, 21)), blockLength),
+		2*blockLength + reduce(uint32(bits.RotateLeft64(h, 42)), blockLength),
+	}
+}
+
+// reduce maps h to [0, n) without a division.
+func reduce(h, n uint32) uint32 {
+	return uint32(uint64(h) * uint64(n) >> 32)
+}
+
+func fingerprint(h uint64, bits int) uint64 {
+	return (h ^ h>>32) & (1<<bits - 1)
+}
+
+func prevalence(tier int) int {
+	p := 1
+	for i := 0; i < tier; i++ {
+		p *= 10
+	}
+	return p
+}
+
+func tierOf(count int) int {
+	t := 0
+	for count >= 10 && t < Tiers-1 {
+		count /= 10
+		t++
+	}
+	return t
+}
@@ -0,0 +1,153 @@
+package breach
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBuilder() *Builder {
+	b := NewBuilder(0.001)
+	b.AddPassword("123456", 37359195)
+	b.AddPassword("password", 9545824)
+	b.AddPassword("correcthorsebatterystaple", 368)
+	for i := 0; i < 997; i++ {
+		b.AddPassword(fmt.Sprintf("breached%d", i), 1)
+	}
+	return b
+}
+
+func TestFilter(t *testing.T) {
+	f := testBuilder().Filter()
+	assert.Equal(t, 1000, f.Len())
+
+	hit, ok := f.LookupPassword("123456")
+	assert.True(t, ok)
+	assert.Equal(t, Hit{Prevalence: 10000000, Rank: 1}, hit)
+	hit, ok = f.LookupPassword("password")
+	assert.True(t, ok)
+	assert.Equal(t, Hit{Prevalence: 1000000, Rank: 2}, hit)
+	hit, ok = f.LookupPassword("correcthorsebatterystaple")
+	assert.True(t, ok)
+	assert.Equal(t, Hit{Prevalence: 100, Rank: 3}, hit)
+	hit, ok = f.Lookup(sha1.Sum([]byte("breached42")))
+	assert.True(t, ok)
+	assert.Equal(t, Hit{Prevalence: 1, Rank: 1000}, hit)
+
+	// passwords are case sensitive
+	_, ok = f.LookupPassword("Password")
+	assert.False(t, ok)
+	// a password added twice is found in its most common tier
+	b := NewBuilder(0.001)
+	b.AddPassword("password", 1000)
+	b.AddPassword("password", 10)
+	hit, ok = b.Filter().LookupPassword("password")
+	assert.True(t, ok)
+	assert.Equal(t, Hit{Prevalence: 1000, Rank: 1}, hit)
+}
+
+func TestFilterFalsePositiveRate(t *testing.T) {
+	f := testBuilder().Filter()
+	positives := 0
+	for i := 0; i < 100000; i++ {
+		if _, ok := f.LookupPassword(fmt.Sprintf("unbreached%d", i)); ok {
+			positives++
+		}
+	}
+	// a lookup has a false positive rate under 0.001, whatever the number of tiers
+	assert.Less(t, positives, 200)
+}
+
+func TestBuilderSpill(t *testing.T) {
+	// enough passwords for 2 shards, spilled 4 times
+	spilled, inMemory := NewBuilder(1e-6), NewBuilder(1e-6)
+	spilled.SpillSize = 40000
+	for i := 0; i < 2*minShardLen+1000; i++ {
+		spilled.AddPassword(fmt.Sprintf("breached%d", i), i)
+		inMemory.AddPassword(fmt.Sprintf("breached%d", i), i)
+	}
+	require.NotEmpty(t, spilled.dir)
+	assert.Empty(t, inMemory.dir)
+
+	var want, got bytes.Buffer
+	_, err := inMemory.WriteTo(&want)
+	require.NoError(t, err)
+	_, err = spilled.WriteTo(&got)
+	require.NoError(t, err)
+	assert.Equal(t, want.Bytes(), got.Bytes())
+
+	f, err := New(got.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 1, f.shardBits)
+	for i := 0; i < 2*minShardLen+1000; i += 997 {
+		hit, ok := f.LookupPassword(fmt.Sprintf("breached%d", i))
+		assert.True(t, ok, i)
+		assert.Equal(t, prevalence(tierOf(i)), hit.Prevalence, i)
+	}
+
+	dir := spilled.dir
+	assert.NoError(t, spilled.Close())
+	_, err = os.Stat(dir)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.NoError(t, inMemory.Close())
+}
+
+func TestFilterEncoding(t *testing.T) {
+	b := testBuilder()
+	var buf bytes.Buffer
+	n, err := b.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	f, err := New(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, b.Filter(), f)
+
+	path := filepath.Join(t.TempDir(), "breached.zxb")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+	f, err = Open(path)
+	require.NoError(t, err)
+	hit, ok := f.LookupPassword("password")
+	assert.True(t, ok)
+	assert.Equal(t, 2, hit.Rank)
+	assert.NoError(t, f.Close())
+
+	data := buf.Bytes()
+	for _, invalid := range [][]byte{nil, []byte("ZXB0"), data[:10], data[:100], data[:len(data)-1]} {
+		_, err := New(invalid)
+		assert.Error(t, err)
+	}
+}
+
+func TestReadHashes(t *testing.T) {
+	input := "7C4A8D09CA3762AF61E59520943DC26494F8941B:37359195\n\n" +
+		"5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8\n"
+	type read struct {
+		digest [sha1.Size]byte
+		count  int
+	}
+	var got []read
+	require.NoError(t, ReadHashes(strings.NewReader(input), func(digest [sha1.Size]byte, count int) error {
+		got = append(got, read{digest, count})
+		return nil
+	}))
+	assert.Equal(t, []read{
+		{sha1.Sum([]byte("123456")), 37359195},
+		{sha1.Sum([]byte("password")), 1},
+	}, got)
+
+	nop := func([sha1.Size]byte, int) error { return nil }
+	assert.EqualError(t, ReadHashes(strings.NewReader("123456\n"), nop), `line 1: invalid SHA-1 digest "123456"`)
+	assert.ErrorContains(t, ReadHashes(strings.NewReader(strings.Repeat("z", 40)), nop), "line 1: invalid SHA-1 digest")
+	assert.ErrorContains(t, ReadHashes(strings.NewReader(strings.Repeat("a", 40)+":many"), nop), "line 1: invalid count")
+	assert.EqualError(t, ReadHashes(strings.NewReader(input), func([sha1.Size]byte, int) error {
+		return fmt.Errorf("stop")
+	}), "stop")
+}
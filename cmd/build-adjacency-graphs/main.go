@@ -0,0 +1,97 @@
+// Command build-adjacency-graphs generates the built-in keyboard graphs of the
+// adjacency package from layout descriptions.
+//
+// Usage:
+//
+//	build-adjacency-graphs output.go layout...
+//
+// Each layout file is described in the format read by adjacency.ParseLayout. The graphs
+// are written in the given order as Go map literals, so that they are built without
+// parsing at runtime.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akara-io/zxcvbn/adjacency"
+)
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s output.go layout...\n", filepath.Base(os.Args[0]))
+		os.Exit(2)
+	}
+	var graphs []*adjacency.Graph
+	for _, path := range os.Args[2:] {
+		g, err := adjacency.LoadLayout(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(1)
+		}
+		graphs = append(graphs, g)
+	}
+	src, err := generate(graphs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(os.Args[1], src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the gofmt-ed source of the graphs.
+func generate(graphs []*adjacency.Graph) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by cmd/build-adjacency-graphs from the layouts directory. DO NOT EDIT.\n\n")
+	b.WriteString("package adjacency\n\n")
+	b.WriteString("// builtinGraphs lists the graphs of the built-in layouts.\n")
+	b.WriteString("var builtinGraphs = []struct {\n\tname  string\n\tgraph func() map[string][]string\n}{\n")
+	for _, g := range graphs {
+		fmt.Fprintf(&b, "{%s, adjacencyGraph%s},\n", strconv.Quote(g.Name), funcName(g.Name))
+	}
+	b.WriteString("}\n")
+	for _, g := range graphs {
+		fmt.Fprintf(&b, "\nfunc adjacencyGraph%s() map[string][]string {\nreturn map[string][]string{\n", funcName(g.Name))
+		chars := make([]string, 0, len(g.Graph))
+		for c := range g.Graph {
+			chars = append(chars, c)
+		}
+		sort.Strings(chars)
+		for _, c := range chars {
+			adjacents := make([]string, len(g.Graph[c]))
+			for i, a := range g.Graph[c] {
+				adjacents[i] = quote(a)
+			}
+			fmt.Fprintf(&b, "%s: {%s},\n", quote(c), strings.Join(adjacents, ", "))
+		}
+		b.WriteString("}\n}\n")
+	}
+	return format.Source(b.Bytes())
+}
+
+// funcName turns a graph name like mac_keypad into MacKeypad.
+func funcName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// quote returns s as a raw string literal when possible, which reads better for keys
+// like \ or ".
+func quote(s string) string {
+	if strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
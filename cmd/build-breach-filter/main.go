@@ -0,0 +1,80 @@
+// Command build-breach-filter builds a breach.Filter from lists of SHA-1 digests of
+// breached passwords.
+//
+// Usage:
+//
+//	build-breach-filter [-p rate] output.zxb input.txt...
+//
+// Each line of the inputs holds a hexadecimal SHA-1 digest, optionally followed by a
+// colon and the number of times the password was seen, like in the Have I Been Pwned
+// downloads. The inputs are read once, the passwords being spilled to temporary files
+// by leading bits of their digest, so that the filter is built one shard at a time:
+// about 4GB of temporary files and 200MB of memory for 500 million passwords.
+//
+// The false positive rate is that of a lookup: passwords are looked up as a few tokens,
+// their words and what is left without the digits and symbols at their ends, so they
+// are taken for breached ones with a rate a few times higher.
+package main
+
+import (
+	"crypto/sha1"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/akara-io/zxcvbn/breach"
+)
+
+func main() {
+	rate := flag.Float64("p", 1e-6, "false positive rate per lookup")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [-p rate] output.zxb input.txt...\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 2 || !(*rate > 0 && *rate < 1) {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := build(flag.Arg(0), flag.Args()[1:], *rate); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func build(output string, inputs []string, rate float64) error {
+	b := breach.NewBuilder(rate)
+	defer b.Close()
+	if err := readInputs(inputs, func(digest [sha1.Size]byte, count int) error {
+		b.Add(digest, count)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if _, err := b.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readInputs(inputs []string, fn func(digest [sha1.Size]byte, count int) error) error {
+	for _, input := range inputs {
+		f, err := os.Open(input)
+		if err != nil {
+			return err
+		}
+		err = breach.ReadHashes(f, fn)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", input, err)
+		}
+	}
+	return nil
+}
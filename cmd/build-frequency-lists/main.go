@@ -0,0 +1,231 @@
+// Command build-frequency-lists generates zxcvbn's packed ranked dictionaries
+// from word frequency data.
+//
+// Usage:
+//
+//	build-frequency-lists [-lang code] data-dir output.zxd
+//
+// data-dir should contain one file per list, each line holding a token, optionally
+// followed by its count, from the most to the least common.
+//
+// dictionaries controls which frequency data will be included and at maximum how many
+// tokens per dictionary.
+//
+// With -lang, data-dir holds the lists of another language than English, like
+// data/lang/fr, which are named after it and languageDictionaries, like fr_words.
+// The default lists are generated with:
+//
+//	build-frequency-lists data frequency/lists.zxd
+//
+// and the lists of a language with:
+//
+//	build-frequency-lists -lang fr data/lang/fr frequency/lang/fr/lists.zxd
+//
+// If a token appears in multiple frequency lists, it will only appear once in the output,
+// in the dictionary where it has lowest rank.
+//
+// Short tokens, if rare, are also filtered out. If a token has higher rank than
+// 10**(token.length), it will be excluded because a bruteforce match would have given
+// it a lower guess score.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
+
+// dictionaries maps dict name to num words. 0 means "include all words".
+var dictionaries = map[string]int{
+	"us_tv_and_film":    30000,
+	"english_wikipedia": 30000,
+	"passwords":         30000,
+	"surnames":          10000,
+	"male_names":        0,
+	"female_names":      0,
+}
+
+// precedence breaks ties between tokens having the same rank in several lists:
+// the token is kept in the list coming first.
+var precedence = []string{
+	"english_wikipedia",
+	"us_tv_and_film",
+	"surnames",
+	"passwords",
+	"male_names",
+	"female_names",
+}
+
+// languageDictionaries maps the lists of a language to num words, like dictionaries.
+// They are named after the language: the words of data/lang/fr/words.txt are fr_words.
+var languageDictionaries = map[string]int{
+	"words":       30000,
+	"surnames":    10000,
+	"first_names": 0,
+}
+
+// languagePrecedence is the precedence of languageDictionaries.
+var languagePrecedence = []string{
+	"words",
+	"surnames",
+	"first_names",
+}
+
+// settings returns the dictionaries and precedence of the lists of language lang,
+// the default ones if lang is empty.
+func settings(lang string) (map[string]int, []string) {
+	if lang == "" {
+		return dictionaries, precedence
+	}
+	dicts := make(map[string]int, len(languageDictionaries))
+	for name, limit := range languageDictionaries {
+		dicts[lang+"_"+name] = limit
+	}
+	prec := make([]string, len(languagePrecedence))
+	for i, name := range languagePrecedence {
+		prec[i] = lang + "_" + name
+	}
+	return dicts, prec
+}
+
+func main() {
+	lang := flag.String("lang", "", "language `code` of the lists of data-dir, like fr")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [-lang code] data-dir output.zxd\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dicts, prec := settings(*lang)
+	lists, err := parseFrequencyLists(flag.Arg(0), *lang, dicts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(flag.Arg(1), packed.Encode(filterFrequencyLists(lists, dicts, prec)), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// parseFrequencyLists returns {list_name: {token: rank}}, as tokens and ranks occur in each file.
+// The lists of a language are prefixed with its code.
+func parseFrequencyLists(dataDir, lang string, dictionaries map[string]int) (map[string]map[string]int, error) {
+	files, err := os.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	lists := make(map[string]map[string]int)
+	for _, file := range files {
+		if file.IsDir() {
+			continue // like the lists of other languages in data/lang
+		}
+		name := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+		if lang != "" {
+			name = lang + "_" + name
+		}
+		if _, ok := dictionaries[name]; !ok {
+			fmt.Fprintf(os.Stderr, "Warning: %s appears in %s directory but not in dictionaries settings. Excluding.\n", name, dataDir)
+			continue
+		}
+		tokenToRank, err := parseFrequencyList(filepath.Join(dataDir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		lists[name] = tokenToRank
+	}
+	for name := range dictionaries {
+		if _, ok := lists[name]; !ok {
+			fmt.Fprintf(os.Stderr, "Warning: %s appears in dictionaries settings but not in %s directory. Excluding.\n", name, dataDir)
+		}
+	}
+	return lists, nil
+}
+
+func parseFrequencyList(path string) (map[string]int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	tokenToRank := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	for rank := 1; scanner.Scan(); rank++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("%s: empty line %d", path, rank)
+		}
+		tokenToRank[fields[0]] = rank
+	}
+	return tokenToRank, scanner.Err()
+}
+
+func isRareAndShort(token string, rank int) bool {
+	return float64(rank) >= math.Pow(10, float64(len([]rune(token))))
+}
+
+func hasCommaOrDoubleQuote(token string) bool {
+	return strings.ContainsAny(token, `,"`)
+}
+
+// filterFrequencyLists filters frequency data according to:
+//   - filter out short tokens if they are too rare.
+//   - filter out tokens if they already appear in another dict at lower rank.
+//   - cut off final freq_list at limits set in dictionaries, if any.
+func filterFrequencyLists(lists map[string]map[string]int, dictionaries map[string]int, precedence []string) map[string][]string {
+	var names []string
+	for _, name := range precedence {
+		if _, ok := lists[name]; ok {
+			names = append(names, name)
+		}
+	}
+
+	minimumRank := make(map[string]int)    // token -> lowest token rank across all lists
+	minimumName := make(map[string]string) // token -> list name with lowest token rank
+	for _, name := range names {
+		for token, rank := range lists[name] {
+			if min, ok := minimumRank[token]; !ok || rank < min {
+				minimumRank[token] = rank
+				minimumName[token] = name
+			}
+		}
+	}
+
+	result := make(map[string][]string, len(lists))
+	for _, name := range names {
+		type tokenRank struct {
+			token string
+			rank  int
+		}
+		var pairs []tokenRank
+		for token, rank := range lists[name] {
+			if minimumName[token] != name {
+				continue
+			}
+			if isRareAndShort(token, rank) || hasCommaOrDoubleQuote(token) {
+				continue
+			}
+			pairs = append(pairs, tokenRank{token, rank})
+		}
+		sort.Slice(pairs, func(a, b int) bool { return pairs[a].rank < pairs[b].rank })
+		if limit := dictionaries[name]; limit > 0 && len(pairs) > limit {
+			pairs = pairs[:limit]
+		}
+		tokens := make([]string, len(pairs))
+		for i, p := range pairs {
+			tokens[i] = p.token
+		}
+		result[name] = tokens
+	}
+	return result
+}
@@ -1,5 +1,7 @@
-
-python build_frequency_lists.py ../data ../frequency/lists.go
-go fmt ../frequency/lists.go
-python build_keyboard_adjacency_graphs.py ../adjacency/graphs.go
-go fmt ../adjacency/graphs.go
\ No newline at end of file
+go run ../cmd/build-frequency-lists ../data ../frequency/lists.zxd
+go run ../cmd/build-frequency-lists -lang de ../data/lang/de ../frequency/lang/de/lists.zxd
+go run ../cmd/build-frequency-lists -lang es ../data/lang/es ../frequency/lang/es/lists.zxd
+go run ../cmd/build-frequency-lists -lang fr ../data/lang/fr ../frequency/lang/fr/lists.zxd
+go run ../cmd/build-frequency-lists -lang pt ../data/lang/pt ../frequency/lang/pt/lists.zxd
+go run ../cmd/build-frequency-lists -lang zh ../data/lang/zh ../frequency/lang/zh/lists.zxd
+go run ../cmd/build-adjacency-graphs ../adjacency/graphs.go ../adjacency/layouts/*.txt
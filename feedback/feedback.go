@@ -1,6 +1,8 @@
 package feedback
 
 import (
+	"fmt"
+
 	"github.com/akara-io/zxcvbn/match"
 	"github.com/akara-io/zxcvbn/scoring"
 	"strings"
@@ -51,8 +53,21 @@ var defaultFeedback = Feedback{
 	},
 }
 
+// Advisor gives feedback on passwords. An Advisor is immutable and safe for concurrent
+// use. Its zero value gives the built-in feedback.
+type Advisor struct {
+	// Regexes is the feedback given for the matches of the regexes with the given names.
+	// It must not be modified once the Advisor is in use.
+	Regexes map[string]Feedback
+}
+
 // GetFeedback returns feedback on a password based on its score and sequence of matches
 func GetFeedback(score int, sequence []*match.Match) Feedback {
+	return Advisor{}.GetFeedback(score, sequence)
+}
+
+// GetFeedback returns feedback on a password based on its score and sequence of matches.
+func (a Advisor) GetFeedback(score int, sequence []*match.Match) Feedback {
 	// Starting feedback
 	if len(sequence) == 0 {
 		return defaultFeedback
@@ -70,7 +85,7 @@ func GetFeedback(score int, sequence []*match.Match) Feedback {
 			longestMatch = m
 		}
 	}
-	feedback := getMatchFeedback(longestMatch, len(sequence) == 1)
+	feedback := a.getMatchFeedback(longestMatch, len(sequence) == 1)
 	extraFeedback := "Add another word or two. Uncommon words are better."
 	if feedback != nil {
 		feedback = feedback.SuggestFirst(extraFeedback)
@@ -80,7 +95,18 @@ func GetFeedback(score int, sequence []*match.Match) Feedback {
 	return *feedback
 }
 
-func getMatchFeedback(match *match.Match, isSoleMatch bool) *Feedback {
+// BreachedWarning is the warning given for a password known to have appeared in a data breach.
+const BreachedWarning = "This password has appeared in a data breach"
+
+const breachedSuggestion = "Avoid passwords you or others have used elsewhere"
+
+// GetBreachedFeedback returns feedback on a password reported by a breach check,
+// whatever its matches.
+func GetBreachedFeedback() Feedback {
+	return *New().Warn(BreachedWarning).Suggest(breachedSuggestion)
+}
+
+func (a Advisor) getMatchFeedback(match *match.Match, isSoleMatch bool) *Feedback {
 	var f *Feedback
 
 	switch match.Pattern {
@@ -108,16 +134,33 @@ func getMatchFeedback(match *match.Match, isSoleMatch bool) *Feedback {
 			Suggest("Avoid sequences")
 
 	case "regex":
-		if match.RegexName == "recent_year" {
+		if custom, ok := a.Regexes[match.RegexName]; ok {
+			f = New().Warn(custom.Warning)
+			for _, s := range custom.Suggestions {
+				f = f.Suggest(s)
+			}
+		} else if match.RegexName == "recent_year" {
 			f = New().Warn("Recent years are easy to guess").
 				Suggest("Avoid recent years").
 				Suggest("Avoid years that are associated with you")
 		}
 
+	case "passphrase":
+		f = New().Warn(fmt.Sprintf("Passphrases of %d common words are easy to guess", match.WordCount)).
+			Suggest("Separators and capitalization between words don't help very much")
+
 	case "date":
 		f = New().Warn("Dates are often easy to guess").
 			Suggest("Avoid dates and years that are associated with you")
 
+	case "breached":
+		if isSoleMatch {
+			f = New().Warn(BreachedWarning)
+		} else {
+			f = New().Warn("This contains a password that has appeared in a data breach")
+		}
+		f = f.Suggest(breachedSuggestion)
+
 	default:
 		f = nil
 	}
@@ -129,7 +172,7 @@ func getDictionaryMatchFeedback(match *match.Match, isSoleMatch bool) *Feedback
 	f := New()
 
 	if match.DictionaryName == "passwords" {
-		if isSoleMatch && !match.L33t && !match.Reversed {
+		if isSoleMatch && !match.L33t && !match.Reversed && match.Layout == "" {
 			if match.Rank <= 10 {
 				f = f.Warn("This is a top-10 common password")
 			} else if match.Rank <= 100 {
@@ -140,11 +183,13 @@ func getDictionaryMatchFeedback(match *match.Match, isSoleMatch bool) *Feedback
 		} else if match.Guesses <= 10000 {
 			f = f.Warn("This is similar to a commonly used password")
 		}
-	} else if match.DictionaryName == "english_wikipedia" {
+	} else if match.DictionaryName == "english_wikipedia" || strings.HasSuffix(match.DictionaryName, "_words") {
+		// the words of English Wikipedia or of a language, like fr_words
 		if isSoleMatch {
 			f = f.Warn("A word by itself is easy to guess")
 		}
-	} else if contains(match.DictionaryName, []string{"surnames", "male_names", "female_names"}) {
+	} else if contains(match.DictionaryName, []string{"surnames", "male_names", "female_names"}) ||
+		strings.HasSuffix(match.DictionaryName, "_surnames") || strings.HasSuffix(match.DictionaryName, "_first_names") {
 		if isSoleMatch {
 			f = f.Warn("Names and surnames by themselves are easy to guess")
 		} else {
@@ -167,6 +212,13 @@ func getDictionaryMatchFeedback(match *match.Match, isSoleMatch bool) *Feedback
 		f = f.Suggest("Predictable substitutions like '@' instead of 'a' don't help very much")
 	}
 
+	if match.Layout != "" {
+		if isSoleMatch && f.Warning == "" {
+			f = f.Warn("Words typed with the wrong keyboard layout are easy to guess")
+		}
+		f = f.Suggest("Typing a word with another keyboard layout active doesn't hide it")
+	}
+
 	return f
 }
 
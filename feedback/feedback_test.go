@@ -2,7 +2,9 @@ package feedback_test
 
 import (
 	"github.com/akara-io/zxcvbn"
+	"github.com/akara-io/zxcvbn/breach"
 	"github.com/akara-io/zxcvbn/feedback"
+	"github.com/akara-io/zxcvbn/match"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -398,3 +400,84 @@ func TestGetFeedback(t *testing.T) {
 		})
 	}
 }
+
+func TestBreachedFeedback(t *testing.T) {
+	b := breach.NewBuilder(1e-6)
+	b.AddPassword("Tr0ub4dour&3", 100)
+	e := zxcvbn.New(zxcvbn.WithBreachFilter(b.Filter()))
+
+	assert.Equal(t, feedback.Feedback{
+		Warning: "This password has appeared in a data breach",
+		Suggestions: []string{
+			"Add another word or two. Uncommon words are better.",
+			"Avoid passwords you or others have used elsewhere",
+		},
+	}, e.PasswordStrength("Tr0ub4dour&3", nil).Feedback)
+
+	assert.Equal(t, "This contains a password that has appeared in a data breach",
+		e.PasswordStrength("Tr0ub4dour&3!", nil).Feedback.Warning)
+}
+
+func TestPassphraseFeedback(t *testing.T) {
+	sequence := []*match.Match{{Pattern: "passphrase", Token: "correct-horse-battery", Separator: "-", WordCount: 3}}
+	assert.Equal(t, feedback.Feedback{
+		Warning: "Passphrases of 3 common words are easy to guess",
+		Suggestions: []string{
+			"Add another word or two. Uncommon words are better.",
+			"Separators and capitalization between words don't help very much",
+		},
+	}, feedback.GetFeedback(1, sequence))
+}
+
+func TestLayoutSwitchFeedback(t *testing.T) {
+	sequence := []*match.Match{{
+		Pattern:        "dictionary",
+		Token:          "ntktajy",
+		MatchedWord:    "телефон",
+		DictionaryName: "ru_words",
+		Layout:         "jcuken",
+		Guesses:        20000,
+	}}
+	assert.Equal(t, feedback.Feedback{
+		Warning: "A word by itself is easy to guess",
+		Suggestions: []string{
+			"Add another word or two. Uncommon words are better.",
+			"Typing a word with another keyboard layout active doesn't hide it",
+		},
+	}, feedback.GetFeedback(1, sequence))
+
+	sequence[0].DictionaryName = "user_inputs"
+	assert.Equal(t, "Words typed with the wrong keyboard layout are easy to guess", feedback.GetFeedback(1, sequence).Warning)
+}
+
+func TestAdvisorRegexes(t *testing.T) {
+	a := feedback.Advisor{Regexes: map[string]feedback.Feedback{
+		"employee_id": {
+			Warning:     "Employee IDs are easy to guess",
+			Suggestions: []string{"Avoid your employee ID"},
+		},
+		"recent_year": {Warning: "Years are easy to guess"},
+	}}
+	sequence := []*match.Match{{Pattern: "regex", Token: "AK-123456", RegexName: "employee_id"}}
+	want := feedback.Feedback{
+		Warning: "Employee IDs are easy to guess",
+		Suggestions: []string{
+			"Add another word or two. Uncommon words are better.",
+			"Avoid your employee ID",
+		},
+	}
+	assert.Equal(t, want, a.GetFeedback(0, sequence))
+	// the feedback of a is left unchanged
+	assert.Equal(t, want, a.GetFeedback(0, sequence))
+
+	// custom feedback replaces the built-in one
+	assert.Equal(t, feedback.Feedback{
+		Warning:     "Years are easy to guess",
+		Suggestions: []string{"Add another word or two. Uncommon words are better."},
+	}, a.GetFeedback(0, []*match.Match{{Pattern: "regex", Token: "1999", RegexName: "recent_year"}}))
+
+	// unknown regexes get the generic feedback
+	assert.Equal(t, feedback.Feedback{
+		Suggestions: []string{"Add another word or two. Uncommon words are better."},
+	}, feedback.GetFeedback(0, sequence))
+}
@@ -0,0 +1,43 @@
+// Package frequency provides the default ranked word lists of zxcvbn and
+// helpers to load more of them.
+package frequency
+
+import (
+	"sync"
+
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
+
+var (
+	defaultOnce sync.Once
+	defaultDict *packed.Dict
+)
+
+// Default returns the default word lists in their packed encoding, which is
+// queried in place without decoding them. They are loaded on first use.
+func Default() *packed.Dict {
+	defaultOnce.Do(func() {
+		d, err := loadDefault()
+		if err != nil {
+			panic(err)
+		}
+		defaultDict = d
+	})
+	return defaultDict
+}
+
+// Lists decodes the default word lists, keyed by name, each ranked from the
+// most to the least common.
+func Lists() map[string][]string {
+	return Default().Lists()
+}
+
+// FrequencyLists returns the default word lists, like Lists.
+//
+// Deprecated: FrequencyLists used to be a variable holding the lists decoded at init,
+// which bloated every binary; it is now a function, so that reading
+// frequency.FrequencyLists[name] becomes frequency.FrequencyLists()[name]. Use Lists,
+// or Default to query the lists without decoding them.
+func FrequencyLists() map[string][]string {
+	return Lists()
+}
@@ -0,0 +1,21 @@
+//go:build !no_embedded_dict
+// +build !no_embedded_dict
+
+package frequency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	assert.Nil(t, defaultDict, "the default lists are loaded on first use")
+	assert.Equal(t, []string{"english_wikipedia", "female_names", "male_names", "passwords", "surnames", "us_tv_and_film"}, Default().Names())
+
+	lists := Lists()
+	assert.Len(t, lists["passwords"], 30000)
+	assert.Equal(t, []string{"123456", "password", "12345678", "qwerty"}, lists["passwords"][:4])
+	assert.Len(t, lists["surnames"], 10000)
+	assert.Equal(t, lists, FrequencyLists())
+}
@@ -0,0 +1,36 @@
+// Package de provides German ranked word lists: common words (de_words), first names
+// (de_first_names) and surnames (de_surnames).
+//
+// They aren't matched by default. Add them to an Estimator with
+// zxcvbn.WithPackedDictionaries(de.Dict()), or to the defaults with
+// matching.RegisterPackedDictionaries(de.Dict()).
+package de
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
+
+// packedLists is generated by cmd/build-frequency-lists from data/lang/de.
+//
+//go:embed lists.zxd
+var packedLists []byte
+
+var (
+	once sync.Once
+	dict *packed.Dict
+)
+
+// Dict returns the German lists in their packed encoding. They are loaded on first use.
+func Dict() *packed.Dict {
+	once.Do(func() {
+		d, err := packed.New(packedLists)
+		if err != nil {
+			panic(err)
+		}
+		dict = d
+	})
+	return dict
+}
@@ -0,0 +1,36 @@
+// Package es provides Spanish ranked word lists: common words (es_words), first names
+// (es_first_names) and surnames (es_surnames).
+//
+// They aren't matched by default. Add them to an Estimator with
+// zxcvbn.WithPackedDictionaries(es.Dict()), or to the defaults with
+// matching.RegisterPackedDictionaries(es.Dict()).
+package es
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
+
+// packedLists is generated by cmd/build-frequency-lists from data/lang/es.
+//
+//go:embed lists.zxd
+var packedLists []byte
+
+var (
+	once sync.Once
+	dict *packed.Dict
+)
+
+// Dict returns the Spanish lists in their packed encoding. They are loaded on first use.
+func Dict() *packed.Dict {
+	once.Do(func() {
+		d, err := packed.New(packedLists)
+		if err != nil {
+			panic(err)
+		}
+		dict = d
+	})
+	return dict
+}
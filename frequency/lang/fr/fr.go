@@ -0,0 +1,36 @@
+// Package fr provides French ranked word lists: common words (fr_words), first names
+// (fr_first_names) and surnames (fr_surnames).
+//
+// They aren't matched by default. Add them to an Estimator with
+// zxcvbn.WithPackedDictionaries(fr.Dict()), or to the defaults with
+// matching.RegisterPackedDictionaries(fr.Dict()).
+package fr
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
+
+// packedLists is generated by cmd/build-frequency-lists from data/lang/fr.
+//
+//go:embed lists.zxd
+var packedLists []byte
+
+var (
+	once sync.Once
+	dict *packed.Dict
+)
+
+// Dict returns the French lists in their packed encoding. They are loaded on first use.
+func Dict() *packed.Dict {
+	once.Do(func() {
+		d, err := packed.New(packedLists)
+		if err != nil {
+			panic(err)
+		}
+		dict = d
+	})
+	return dict
+}
@@ -0,0 +1,37 @@
+// Package lang gives access by language code to the optional word lists of other
+// languages than English, for instance to pick them from the locale of a user.
+// Importing it embeds the lists of every language; import a single language package,
+// like lang/fr, to embed only its lists.
+package lang
+
+import (
+	"github.com/akara-io/zxcvbn/frequency/lang/de"
+	"github.com/akara-io/zxcvbn/frequency/lang/es"
+	"github.com/akara-io/zxcvbn/frequency/lang/fr"
+	"github.com/akara-io/zxcvbn/frequency/lang/pt"
+	"github.com/akara-io/zxcvbn/frequency/lang/zh"
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
+
+var dicts = map[string]func() *packed.Dict{
+	"de": de.Dict,
+	"es": es.Dict,
+	"fr": fr.Dict,
+	"pt": pt.Dict,
+	"zh": zh.Dict,
+}
+
+// Codes returns the codes of the languages having word lists, sorted.
+func Codes() []string {
+	return []string{"de", "es", "fr", "pt", "zh"}
+}
+
+// Dict returns the lists of the language with the given code, like fr, or false if
+// there are none. Their names start with the code, like fr_words.
+func Dict(code string) (*packed.Dict, bool) {
+	d, ok := dicts[code]
+	if !ok {
+		return nil, false
+	}
+	return d(), true
+}
@@ -0,0 +1,53 @@
+package lang
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDict(t *testing.T) {
+	codes := Codes()
+	assert.True(t, sort.StringsAreSorted(codes))
+	assert.Len(t, dicts, len(codes))
+	for _, code := range codes {
+		d, ok := Dict(code)
+		require.True(t, ok, code)
+		assert.Equal(t, []string{code + "_first_names", code + "_surnames", code + "_words"}, d.Names())
+		for name, words := range d.Lists() {
+			assert.NotEmpty(t, words, name)
+		}
+		again, _ := Dict(code)
+		assert.Same(t, d, again, "the lists are loaded once")
+	}
+
+	for _, tt := range []struct{ code, word, list string }{
+		{"fr", "bonjour", "fr_words"},
+		{"fr", "dupont", "fr_surnames"},
+		{"de", "schmetterling", ""},
+		{"de", "schatz", "de_words"},
+		{"de", "müller", "de_surnames"},
+		{"es", "contraseña", "es_words"},
+		{"es", "lucía", "es_first_names"},
+		{"pt", "saudade", "pt_words"},
+		{"pt", "joão", "pt_first_names"},
+		{"zh", "woaini", "zh_words"},
+		{"zh", "zhang", "zh_surnames"},
+	} {
+		d, _ := Dict(tt.code)
+		var lists []string
+		for _, e := range d.Lookup(tt.word) {
+			lists = append(lists, d.Names()[e.List])
+		}
+		if tt.list == "" {
+			assert.Empty(t, lists, tt.word)
+		} else {
+			assert.Equal(t, []string{tt.list}, lists, tt.word)
+		}
+	}
+
+	_, ok := Dict("en")
+	assert.False(t, ok)
+}
@@ -0,0 +1,36 @@
+// Package pt provides Portuguese ranked word lists: common words (pt_words), first names
+// (pt_first_names) and surnames (pt_surnames).
+//
+// They aren't matched by default. Add them to an Estimator with
+// zxcvbn.WithPackedDictionaries(pt.Dict()), or to the defaults with
+// matching.RegisterPackedDictionaries(pt.Dict()).
+package pt
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
+
+// packedLists is generated by cmd/build-frequency-lists from data/lang/pt.
+//
+//go:embed lists.zxd
+var packedLists []byte
+
+var (
+	once sync.Once
+	dict *packed.Dict
+)
+
+// Dict returns the Portuguese lists in their packed encoding. They are loaded on first use.
+func Dict() *packed.Dict {
+	once.Do(func() {
+		d, err := packed.New(packedLists)
+		if err != nil {
+			panic(err)
+		}
+		dict = d
+	})
+	return dict
+}
@@ -0,0 +1,37 @@
+// Package zh provides Chinese ranked word lists: common words (zh_words), first names
+// (zh_first_names) and surnames (zh_surnames).
+// Words and names are written in pinyin, without tones or spaces, like woaini.
+//
+// They aren't matched by default. Add them to an Estimator with
+// zxcvbn.WithPackedDictionaries(zh.Dict()), or to the defaults with
+// matching.RegisterPackedDictionaries(zh.Dict()).
+package zh
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
+
+// packedLists is generated by cmd/build-frequency-lists from data/lang/zh.
+//
+//go:embed lists.zxd
+var packedLists []byte
+
+var (
+	once sync.Once
+	dict *packed.Dict
+)
+
+// Dict returns the Chinese lists in their packed encoding. They are loaded on first use.
+func Dict() *packed.Dict {
+	once.Do(func() {
+		d, err := packed.New(packedLists)
+		if err != nil {
+			panic(err)
+		}
+		dict = d
+	})
+	return dict
+}
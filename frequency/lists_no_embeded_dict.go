@@ -1,26 +1,28 @@
+//go:build no_embedded_dict
 // +build no_embedded_dict
 
 package frequency
 
 import (
-	"encoding/json"
 	"os"
-)
 
-var FrequencyLists map[string][]string
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
 
-func init() {
-	file := os.Getenv("ZXCVBN_DEFAULT_DICTIONARIES_JSON")
-	if file == "" {
-		panic("missing ZXCVBN_DEFAULT_DICTIONARIES_JSON env")
-	}
-	fd, err := os.Open(file)
-	if err != nil {
-		panic(err)
-	}
-	defer fd.Close()
-	err = json.NewDecoder(fd).Decode(&FrequencyLists)
-	if err != nil {
-		panic(err)
+// loadDefault reads the lists from the JSON file named by the
+// ZXCVBN_DEFAULT_DICTIONARIES_JSON environment variable, if any.
+// Without it, the lists are empty: dictionaries can be added at runtime instead.
+func loadDefault() (*packed.Dict, error) {
+	var lists map[string][]string
+	if file := os.Getenv("ZXCVBN_DEFAULT_DICTIONARIES_JSON"); file != "" {
+		fd, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer fd.Close()
+		if lists, err = ReadJSON(fd); err != nil {
+			return nil, err
+		}
 	}
+	return packed.New(packed.Encode(lists))
 }
@@ -0,0 +1,122 @@
+package frequency
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format is the encoding of a word list.
+type Format int
+
+const (
+	// Text lists one word per line, from the most to the least common.
+	Text Format = iota
+	// Counts lists a word followed by its number of occurrences on each line, in any order,
+	// like the files of the data directory.
+	Counts
+)
+
+// ReadList reads a word list in the given format and returns its words ranked from
+// the most to the least common. Empty lines are ignored.
+func ReadList(r io.Reader, format Format) ([]string, error) {
+	type counted struct {
+		word  string
+		count int
+	}
+	var words []string
+	var counts []counted
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		switch format {
+		case Text:
+			words = append(words, text)
+		case Counts:
+			fields := strings.Fields(text)
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: expected a word and a count, got %q", line, text)
+			}
+			count, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid count: %w", line, err)
+			}
+			counts = append(counts, counted{fields[0], count})
+		default:
+			return nil, fmt.Errorf("unknown word list format %d", format)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if format == Counts {
+		sort.SliceStable(counts, func(i, j int) bool {
+			return counts[i].count > counts[j].count
+		})
+		words = make([]string, len(counts))
+		for i, c := range counts {
+			words[i] = c.word
+		}
+	}
+	return words, nil
+}
+
+// ReadJSON reads ranked word lists keyed by name from a JSON object, like the result of Lists.
+func ReadJSON(r io.Reader) (map[string][]string, error) {
+	var lists map[string][]string
+	if err := json.NewDecoder(r).Decode(&lists); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// LoadList reads the word list stored in file name of fsys.
+func LoadList(fsys fs.FS, name string, format Format) ([]string, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	words, err := ReadList(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return words, nil
+}
+
+// LoadJSON reads the word lists stored in JSON file name of fsys.
+func LoadJSON(fsys fs.FS, name string) (map[string][]string, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	lists, err := ReadJSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return lists, nil
+}
+
+// LoadFile reads the word list stored in the file at path.
+func LoadFile(path string, format Format) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	words, err := ReadList(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return words, nil
+}
@@ -0,0 +1,67 @@
+package frequency
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadList(t *testing.T) {
+	words, err := ReadList(strings.NewReader("mary\n\n  patricia \nlinda\n"), Text)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mary", "patricia", "linda"}, words)
+
+	// counts are ranked from the most to the least common, ties keeping their order
+	words, err = ReadList(strings.NewReader("of 5970\nthe 12493\nand 5970\n"), Counts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"the", "of", "and"}, words)
+
+	_, err = ReadList(strings.NewReader("the 12493\nof\n"), Counts)
+	assert.EqualError(t, err, `line 2: expected a word and a count, got "of"`)
+	_, err = ReadList(strings.NewReader("the many\n"), Counts)
+	assert.ErrorContains(t, err, "line 1: invalid count")
+	_, err = ReadList(strings.NewReader("the\n"), Format(42))
+	assert.Error(t, err)
+}
+
+func TestReadJSON(t *testing.T) {
+	lists, err := ReadJSON(strings.NewReader(`{"names": ["mary", "john"], "words": ["the"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"names": {"mary", "john"}, "words": {"the"}}, lists)
+
+	_, err = ReadJSON(strings.NewReader(`["mary"]`))
+	assert.Error(t, err)
+}
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"lists/names.txt":  {Data: []byte("mary\njohn\n")},
+		"lists/counts.txt": {Data: []byte("john 2\nmary 3\n")},
+		"lists/all.json":   {Data: []byte(`{"names": ["mary"]}`)},
+		"lists/bad.txt":    {Data: []byte("john two\n")},
+	}
+
+	words, err := LoadList(fsys, "lists/names.txt", Text)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mary", "john"}, words)
+
+	words, err = LoadList(fsys, "lists/counts.txt", Counts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mary", "john"}, words)
+
+	lists, err := LoadJSON(fsys, "lists/all.json")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"names": {"mary"}}, lists)
+
+	_, err = LoadList(fsys, "lists/bad.txt", Counts)
+	assert.ErrorContains(t, err, "lists/bad.txt: line 1")
+	_, err = LoadList(fsys, "lists/missing.txt", Text)
+	assert.Error(t, err)
+
+	words, err = LoadFile("../data/male_names.txt", Text)
+	require.NoError(t, err)
+	assert.Equal(t, "james", words[0])
+}
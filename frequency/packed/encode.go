@@ -0,0 +1,120 @@
+package packed
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// wideChildCount is the number of children above which a node is wide,
+// its children being binary searched.
+const wideChildCount = 8
+
+// Encode returns the packed encoding of lists, each list being ranked by its order.
+// Words are lowercased; a word occurring several times in a list keeps its last rank.
+func Encode(lists map[string][]string) []byte {
+	names := make([]string, 0, len(lists))
+	for name := range lists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make(map[string][]Entry)
+	for i, name := range names {
+		for rank, w := range lists[name] {
+			w = strings.ToLower(w)
+			e := entries[w]
+			if n := len(e); n > 0 && e[n-1].List == i {
+				e[n-1].Rank = rank + 1
+			} else {
+				e = append(e, Entry{List: i, Rank: rank + 1})
+			}
+			entries[w] = e
+		}
+	}
+	words := make([]string, 0, len(entries))
+	for w := range entries {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+
+	var header, nodes bytes.Buffer
+	header.WriteString(magic)
+	writeUint32(&header, len(names))
+	for _, name := range names {
+		writeUvarint(&header, len(name))
+		header.WriteString(name)
+	}
+	writeUint32(&header, len(words))
+	root := encodeNode(&nodes, words, entries, 0)
+	writeUint32(&header, root)
+	return append(header.Bytes(), nodes.Bytes()...)
+}
+
+// encodeNode writes the node of the words sharing their first depth bytes, after
+// its children, and returns its offset. words must be sorted.
+func encodeNode(b *bytes.Buffer, words []string, entries map[string][]Entry, depth int) int {
+	var own []Entry
+	if len(words) > 0 && len(words[0]) == depth {
+		own = entries[words[0]]
+		words = words[1:]
+	}
+	type edge struct {
+		r   rune
+		off int
+	}
+	var edges []edge
+	for len(words) > 0 {
+		r, size := utf8.DecodeRuneInString(words[0][depth:])
+		prefix := words[0][:depth+size]
+		n := 1
+		for n < len(words) && strings.HasPrefix(words[n], prefix) {
+			n++
+		}
+		edges = append(edges, edge{r, encodeNode(b, words[:n], entries, depth+size)})
+		words = words[n:]
+	}
+
+	off := b.Len()
+	writeUvarint(b, len(own))
+	for _, e := range own {
+		writeUvarint(b, e.List)
+		writeUvarint(b, e.Rank)
+	}
+	wide := len(edges) > wideChildCount
+	if wide {
+		writeUvarint(b, len(edges)<<1|1)
+	} else {
+		writeUvarint(b, len(edges)<<1)
+	}
+	for _, e := range edges {
+		if wide {
+			writeUint32(b, int(e.r))
+			writeUint32(b, off-e.off)
+		} else {
+			writeUvarint(b, int(e.r))
+			writeUvarint(b, off-e.off)
+		}
+	}
+	return off
+}
+
+// Write writes the packed encoding of lists to w.
+func Write(w io.Writer, lists map[string][]string) error {
+	_, err := w.Write(Encode(lists))
+	return err
+}
+
+func writeUint32(b *bytes.Buffer, v int) {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], uint32(v))
+	b.Write(buf[:])
+}
+
+func writeUvarint(b *bytes.Buffer, v int) {
+	var buf [binary.MaxVarintLen64]byte
+	b.Write(buf[:binary.PutUvarint(buf[:], uint64(v))])
+}
@@ -0,0 +1,18 @@
+package packed
+
+import "github.com/akara-io/zxcvbn/internal/mmap"
+
+// Open memory-maps the packed file at path. The Dict must be closed to unmap it.
+func Open(path string) (*Dict, error) {
+	data, close, err := mmap.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	d, err := New(data)
+	if err != nil {
+		close()
+		return nil, err
+	}
+	d.close = close
+	return d, nil
+}
@@ -0,0 +1,320 @@
+// Package packed implements a compact, read-only encoding of ranked word lists.
+//
+// The words of all lists are stored once, in a prefix tree whose nodes hold the
+// rank the word ending there has in every list holding it. Nodes are laid out
+// children first, so that a node refers to its children by their small, variable
+// length distance to it.
+//
+// A Dict is queried in place, without building any map, so it can be embedded in a
+// binary or memory-mapped from a file.
+//
+// Encoding, fixed size integers being little endian:
+//
+//	magic       "ZXD1"
+//	listCount   uint32, then for each list: uvarint name length, name
+//	wordCount   uint32
+//	root        uint32 offset of the root node in nodes
+//	nodes       for each node:
+//	              uvarint entry count, and for each entry: uvarint list index, uvarint rank
+//	              uvarint child count << 1 | wide, and for each child by increasing rune:
+//	                rune, offset of the node minus offset of the child
+//	              both being uint32 in the wide nodes, which have many children and are
+//	              binary searched, and uvarint otherwise
+package packed
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+const magic = "ZXD1"
+
+// Entry is the rank of a word in one of the lists of a Dict.
+type Entry struct {
+	// List is the index of the list in Dict.Names.
+	List int
+	// Rank is the rank of the word in the list, 1 being the most common.
+	Rank int
+}
+
+// Dict is a set of ranked word lists decoded in place from their packed encoding.
+// A Dict is safe for concurrent use.
+type Dict struct {
+	names     []string
+	wordCount int
+	root      int
+	nodes     []byte
+	close     func() error
+}
+
+// New returns the Dict encoded in data, which must not be modified afterwards.
+func New(data []byte) (*Dict, error) {
+	if len(data) < len(magic) || string(data[:len(magic)]) != magic {
+		return nil, errors.New("packed: invalid magic")
+	}
+	d := &Dict{}
+	r := reader{b: data, off: len(magic)}
+	listCount := r.uint32()
+	for i := 0; i < listCount && r.err == nil; i++ {
+		d.names = append(d.names, string(r.bytes(r.uvarint())))
+	}
+	d.wordCount = r.uint32()
+	d.root = r.uint32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	d.nodes = data[r.off:]
+	if d.root >= len(d.nodes) {
+		return nil, errors.New("packed: invalid root offset")
+	}
+	return d, nil
+}
+
+// Close releases the resources of a Dict returned by Open. The Dict must not be used afterwards.
+func (d *Dict) Close() error {
+	if d.close == nil {
+		return nil
+	}
+	err := d.close()
+	d.close = nil
+	return err
+}
+
+// Names returns the names of the lists of d.
+func (d *Dict) Names() []string {
+	return append([]string(nil), d.names...)
+}
+
+// Len returns the number of distinct words of d.
+func (d *Dict) Len() int {
+	return d.wordCount
+}
+
+// Lookup returns the entries of word, lowercased, or nil if no list holds it.
+func (d *Dict) Lookup(word string) []Entry {
+	r := d.Root()
+	for _, c := range word {
+		var ok bool
+		if r, ok = r.Next(c); !ok {
+			return nil
+		}
+	}
+	return r.Entries()
+}
+
+// Each calls fn for every word of d in sorted order, until fn returns false.
+func (d *Dict) Each(fn func(word string, entries []Entry) bool) {
+	d.each(d.root, nil, fn)
+}
+
+func (d *Dict) each(off int, word []byte, fn func(word string, entries []Entry) bool) bool {
+	n := d.node(off)
+	if n.entryCount > 0 && !fn(string(word), n.entries()) {
+		return false
+	}
+	r := reader{b: d.nodes, off: n.children}
+	for i := 0; i < n.childCount; i++ {
+		c, delta := n.edge(&r)
+		child := d.child(off, delta)
+		if r.err != nil || child < 0 {
+			return false
+		}
+		if !d.each(child, utf8.AppendRune(word, c), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Lists decodes the lists of d, keyed by name, each list being ordered by rank.
+func (d *Dict) Lists() map[string][]string {
+	type ranked struct {
+		word string
+		rank int
+	}
+	byList := make([][]ranked, len(d.names))
+	d.Each(func(word string, entries []Entry) bool {
+		for _, e := range entries {
+			if e.List < len(byList) {
+				byList[e.List] = append(byList[e.List], ranked{word, e.Rank})
+			}
+		}
+		return true
+	})
+	lists := make(map[string][]string, len(d.names))
+	for i, words := range byList {
+		sort.SliceStable(words, func(a, b int) bool { return words[a].rank < words[b].rank })
+		list := make([]string, len(words))
+		for k, w := range words {
+			list[k] = w.word
+		}
+		lists[d.names[i]] = list
+	}
+	return lists
+}
+
+// Root returns the Range of all the words of d.
+func (d *Dict) Root() Range {
+	return Range{d: d, off: d.root}
+}
+
+// Range is the set of words of a Dict starting with a given prefix.
+// It allows walking the words of a Dict like a prefix tree.
+type Range struct {
+	d   *Dict
+	off int // offset of the node of the prefix
+}
+
+// Next returns the Range of the words of r continuing with c, lowercased.
+// It reports false if there are none.
+func (r Range) Next(c rune) (Range, bool) {
+	if r.d == nil {
+		return Range{}, false
+	}
+	c = unicode.ToLower(c)
+	n := r.d.node(r.off)
+	cr := reader{b: r.d.nodes, off: n.children}
+	if n.wide {
+		k := sort.Search(n.childCount, func(i int) bool {
+			return rune(binary.LittleEndian.Uint32(r.d.nodes[n.children+8*i:])) >= c
+		})
+		if k == n.childCount {
+			return Range{}, false
+		}
+		cr.off += 8 * k
+	}
+	for i := 0; i < n.childCount; i++ {
+		edge, delta := n.edge(&cr)
+		if cr.err != nil || edge > c {
+			break
+		}
+		if edge == c {
+			if child := r.d.child(r.off, delta); child >= 0 {
+				return Range{d: r.d, off: child}, true
+			}
+			break
+		}
+	}
+	return Range{}, false
+}
+
+// Entries returns the entries of the word equal to the prefix of r, if any.
+func (r Range) Entries() []Entry {
+	if r.d == nil {
+		return nil
+	}
+	return r.d.node(r.off).entries()
+}
+
+// node is a decoded node header.
+type node struct {
+	d          *Dict
+	entryCount int
+	entryStart int // offset of the entries
+	childCount int
+	wide       bool
+	children   int // offset of the children
+}
+
+func (d *Dict) node(off int) node {
+	r := reader{b: d.nodes, off: off}
+	n := node{d: d, entryCount: r.uvarint()}
+	n.entryStart = r.off
+	for i := 0; i < n.entryCount; i++ {
+		r.uvarint()
+		r.uvarint()
+	}
+	children := r.uvarint()
+	n.childCount, n.wide = children>>1, children&1 == 1
+	n.children = r.off
+	if r.err != nil || n.wide && n.children+8*n.childCount > len(d.nodes) {
+		return node{}
+	}
+	return n
+}
+
+// edge reads the rune and the offset delta of the child of n at r.
+func (n node) edge(r *reader) (rune, int) {
+	if n.wide {
+		return rune(r.uint32()), r.uint32()
+	}
+	return rune(r.uvarint()), r.uvarint()
+}
+
+// child returns the offset of the child at delta bytes before the node at off,
+// or -1 if it is invalid. Children always precede their parent, so that walking
+// the nodes ends even with corrupt data.
+func (d *Dict) child(off, delta int) int {
+	if delta <= 0 || delta > off {
+		return -1
+	}
+	return off - delta
+}
+
+func (n node) entries() []Entry {
+	if n.entryCount == 0 {
+		return nil
+	}
+	r := reader{b: n.d.nodes, off: n.entryStart}
+	entries := make([]Entry, 0, n.entryCount)
+	for i := 0; i < n.entryCount && r.err == nil; i++ {
+		entries = append(entries, Entry{List: r.uvarint(), Rank: r.uvarint()})
+	}
+	return entries
+}
+
+// reader decodes the integers and byte strings of an encoded Dict,
+// recording the first error.
+type reader struct {
+	b   []byte
+	off int
+	err error
+}
+
+func (r *reader) uint32() int {
+	if r.err != nil || r.off+4 > len(r.b) {
+		r.fail()
+		return 0
+	}
+	v := binary.LittleEndian.Uint32(r.b[r.off:])
+	r.off += 4
+	return int(v)
+}
+
+func (r *reader) uvarint() int {
+	if r.err != nil || r.off >= len(r.b) {
+		r.fail()
+		return 0
+	}
+	if b := r.b[r.off]; b < 0x80 {
+		r.off++
+		return int(b)
+	}
+	v, n := binary.Uvarint(r.b[r.off:])
+	if n <= 0 || v > 1<<31 {
+		r.fail()
+		return 0
+	}
+	r.off += n
+	return int(v)
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil || r.off+n > len(r.b) {
+		r.fail()
+		return nil
+	}
+	b := r.b[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+func (r *reader) fail() {
+	if r.err == nil {
+		r.err = fmt.Errorf("packed: truncated data at offset %d", r.off)
+	}
+}
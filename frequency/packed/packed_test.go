@@ -0,0 +1,178 @@
+package packed
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLists = map[string][]string{
+	"names":     {"mary", "Patricia", "linda", "marylou"},
+	"words":     {"the", "of", "mary", "théâtre", "先生"},
+	"passwords": {"123456", "password", "pass", "password"},
+}
+
+// wideLists returns lists whose words share a prefix but no further letter,
+// so that their nodes are wide.
+func wideLists() map[string][]string {
+	var words []string
+	for c := 'a'; c <= 'z'; c++ {
+		words = append(words, "x"+string(c), fmt.Sprintf("x%cy", c))
+	}
+	return map[string][]string{"wide": words}
+}
+
+func newTestDict(t *testing.T, lists map[string][]string) *Dict {
+	d, err := New(Encode(lists))
+	require.NoError(t, err)
+	return d
+}
+
+func TestDict(t *testing.T) {
+	d := newTestDict(t, testLists)
+	assert.Equal(t, []string{"names", "passwords", "words"}, d.Names())
+	assert.Equal(t, 11, d.Len())
+
+	assert.Equal(t, []Entry{{List: 0, Rank: 1}, {List: 2, Rank: 3}}, d.Lookup("mary"))
+	assert.Equal(t, []Entry{{List: 0, Rank: 2}}, d.Lookup("patricia"))
+	assert.Equal(t, []Entry{{List: 2, Rank: 4}}, d.Lookup("théâtre"))
+	// duplicated words keep their last rank
+	assert.Equal(t, []Entry{{List: 1, Rank: 4}}, d.Lookup("password"))
+	// words are stored and looked up lowercased
+	assert.Equal(t, []Entry{{List: 0, Rank: 2}}, d.Lookup("PATRICIA"))
+	assert.Nil(t, d.Lookup("mar"))
+	assert.Nil(t, d.Lookup("marylous"))
+	assert.Nil(t, d.Lookup(""))
+}
+
+func TestDictLists(t *testing.T) {
+	for _, lists := range []map[string][]string{testLists, wideLists(), {}} {
+		d := newTestDict(t, lists)
+		want := make(map[string][]string, len(lists))
+		for name := range lists {
+			want[name] = decodeLists(lists)[name]
+		}
+		assert.Equal(t, want, d.Lists())
+	}
+}
+
+// decodeLists returns lists as they are decoded: lowercased, duplicates keeping their last rank.
+func decodeLists(lists map[string][]string) map[string][]string {
+	res := make(map[string][]string)
+	for name, words := range lists {
+		last := make(map[string]int)
+		for i, w := range words {
+			last[strings.ToLower(w)] = i
+		}
+		res[name] = []string{}
+		for i, w := range words {
+			if last[strings.ToLower(w)] == i {
+				res[name] = append(res[name], strings.ToLower(w))
+			}
+		}
+	}
+	return res
+}
+
+func TestDictEach(t *testing.T) {
+	d := newTestDict(t, testLists)
+	var words []string
+	d.Each(func(word string, entries []Entry) bool {
+		assert.Equal(t, d.Lookup(word), entries)
+		words = append(words, word)
+		return len(words) < 5
+	})
+	assert.Equal(t, []string{"123456", "linda", "mary", "marylou", "of"}, words)
+}
+
+func TestRange(t *testing.T) {
+	d := newTestDict(t, testLists)
+	r := d.Root()
+	var found []string
+	word := ""
+	for _, c := range "MaryLouise" {
+		var ok bool
+		if r, ok = r.Next(c); !ok {
+			break
+		}
+		word += string(c)
+		if r.Entries() != nil {
+			found = append(found, word)
+		}
+	}
+	assert.Equal(t, []string{"Mary", "MaryLou"}, found)
+	assert.Nil(t, d.Root().Entries())
+
+	_, ok := d.Root().Next('z')
+	assert.False(t, ok)
+	_, ok = Range{}.Next('a')
+	assert.False(t, ok)
+	assert.Nil(t, Range{}.Entries())
+
+	wide := newTestDict(t, wideLists())
+	for c := 'a'; c <= 'z'; c++ {
+		assert.Equal(t, []Entry{{List: 0, Rank: int(c-'a')*2 + 2}}, wide.Lookup("x"+string(c)+"y"))
+	}
+	assert.Nil(t, wide.Lookup("x{"))
+	assert.Nil(t, wide.Lookup("x0"))
+}
+
+func TestNewInvalid(t *testing.T) {
+	data := Encode(testLists)
+	for _, b := range [][]byte{nil, []byte("ZXD0"), data[:10], data[:len(data)-20]} {
+		_, err := New(b)
+		assert.Error(t, err)
+	}
+
+	// corrupt nodes never panic nor loop
+	for i := 0; i < len(data); i++ {
+		corrupt := append([]byte(nil), data...)
+		corrupt[i] ^= 0xff
+		d, err := New(corrupt)
+		if err != nil {
+			continue
+		}
+		d.Lists()
+		d.Lookup("marylou")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lists.zxd")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, Write(f, testLists))
+	require.NoError(t, f.Close())
+
+	d, err := Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, decodeLists(testLists), d.Lists())
+	assert.NoError(t, d.Close())
+	assert.NoError(t, d.Close())
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing.zxd"))
+	assert.Error(t, err)
+
+	empty := filepath.Join(t.TempDir(), "empty.zxd")
+	require.NoError(t, os.WriteFile(empty, nil, 0o644))
+	_, err = Open(empty)
+	assert.Error(t, err)
+}
+
+func BenchmarkLookup(b *testing.B) {
+	var words []string
+	for i := 0; i < 100000; i++ {
+		words = append(words, fmt.Sprintf("w%x", i*7919))
+	}
+	d, err := New(Encode(map[string][]string{"words": words}))
+	require.NoError(b, err)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Lookup(words[i%len(words)])
+	}
+}
@@ -6,6 +6,7 @@ require (
 	github.com/dlclark/regexp2 v1.10.0
 	github.com/google/go-cmp v0.5.9
 	github.com/stretchr/testify v1.8.2
+	golang.org/x/text v0.14.0
 )
 
 require (
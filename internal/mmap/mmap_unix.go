@@ -0,0 +1,30 @@
+//go:build unix
+
+// Package mmap maps read-only files in memory.
+package mmap
+
+import (
+	"os"
+	"syscall"
+)
+
+// Open memory-maps the file at path. close unmaps it; data must not be used afterwards.
+func Open(path string) (data []byte, close func() error, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	if fi.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	}
+	data, err = syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
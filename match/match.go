@@ -14,11 +14,16 @@ type Match struct {
 	Reversed            bool              `json:"reversed,omitempty"`
 	UppercaseVariations float64           `json:"uppercase_variations,omitempty"`
 	L33tVariations      float64           `json:"l33t_variations,omitempty"`
+	DiacriticVariations float64           `json:"diacritic_variations,omitempty"`
 	MatchedWord         string            `json:"matched_word,omitempty"`
 	Rank                int               `json:"rank,omitempty"`
 	DictionaryName      string            `json:"dictionary_name,omitempty"`
 	L33t                bool              `json:"l33t,omitempty"`
 	Sub                 map[string]string `json:"sub,omitempty"`
+	Diacritics          int               `json:"diacritics,omitempty"`
+	// Layout is the keyboard layout the word is written in when it was typed with
+	// another layout active, like jcuken for пароль typed as gfhjkm on qwerty.
+	Layout string `json:"layout,omitempty"`
 
 	// Sequence
 	Graph         string `json:"graph,omitempty"`
@@ -27,6 +32,7 @@ type Match struct {
 	Ascending     bool   `json:"ascending,omitempty"`
 	Turns         int    `json:"turns,omitempty"`
 	ShiftedCount  int    `json:"shifted_count,omitempty"`
+	AltGrCount    int    `json:"altgr_count,omitempty"`
 
 	// Repeat
 	BaseToken   string   `json:"base_token,omitempty"`
@@ -34,14 +40,23 @@ type Match struct {
 	BaseMatches []*Match `json:"base_matches,omitempty"`
 	RepeatCount int      `json:"repeat_count,omitempty"`
 
+	// Passphrase
+	WordCount int `json:"word_count,omitempty"`
+
 	// Regexp
 	RegexName string `json:"regex_name,omitempty"`
 
+	// Breached
+	Prevalence int `json:"prevalence,omitempty"`
+
 	// Date
 	Year      int     `json:"year,omitempty"`
 	Month     int     `json:"month,omitempty"`
 	Day       int     `json:"day,omitempty"`
 	Separator string  `json:"separator,omitempty"`
+	MonthName string  `json:"month_name,omitempty"`
+	Weekday   string  `json:"weekday,omitempty"`
+	Ordinal   bool    `json:"ordinal,omitempty"`
 	Entropy   float64 `json:"entropy,omitempty"`
 	Guesses   float64 `json:"guesses,omitempty"`
 }
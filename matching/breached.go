@@ -0,0 +1,158 @@
+package matching
+
+import (
+	"context"
+	"crypto/sha1"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/akara-io/zxcvbn/breach"
+	"github.com/akara-io/zxcvbn/match"
+)
+
+// minBreachedLength is the number of characters below which tokens are not looked up
+// in breached passwords: short ones are found in any breach and are better estimated by
+// the other matchers.
+const minBreachedLength = 4
+
+// maxBreachedAffixes is the number of runs of digits or symbols dropped at most from
+// either end of a token to look up what it was appended to, like Tr0ub4dour&3 in
+// Tr0ub4dour&3!.
+const maxBreachedAffixes = 2
+
+type breachedMatch struct {
+	filter *breach.Filter
+}
+
+func (bm breachedMatch) Matches(password string) []*match.Match {
+	matches, _ := bm.matchesContext(context.Background(), password)
+	return matches
+}
+
+func (bm breachedMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
+	if bm.filter == nil {
+		return nil, nil
+	}
+	var matches []*match.Match
+	for _, token := range breachedTokens(password) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		i, j := token[0], token[1]
+		hit, ok := bm.filter.Lookup(sha1.Sum([]byte(password[i:j])))
+		if !ok {
+			continue
+		}
+		matches = append(matches, &match.Match{
+			Pattern:    "breached",
+			I:          i,
+			J:          j - 1,
+			Token:      password[i:j],
+			Rank:       hit.Rank,
+			Prevalence: hit.Prevalence,
+		})
+	}
+	match.Sort(matches)
+	return matches, nil
+}
+
+// breachedTokens returns the byte ranges of the tokens of password looked up in breached
+// passwords: password itself and its whitespace separated words, each also without up to
+// maxBreachedAffixes runs of digits or symbols at either end. Looking up every substring
+// instead would multiply the false positives of the filter.
+func breachedTokens(password string) [][2]int {
+	words := [][2]int{{0, len(password)}}
+	start := -1
+	for i, r := range password {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			words = append(words, [2]int{start, i})
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		}
+	}
+	if start > 0 {
+		words = append(words, [2]int{start, len(password)})
+	}
+
+	var tokens [][2]int
+	seen := make(map[[2]int]bool)
+	for _, w := range words {
+		starts, ends := []int{w[0]}, []int{w[1]}
+		for k := 0; k < maxBreachedAffixes; k++ {
+			next := skipAffix(password[:w[1]], starts[k])
+			if next < 0 {
+				break
+			}
+			starts = append(starts, next)
+		}
+		for k := 0; k < maxBreachedAffixes; k++ {
+			prev := skipAffixBackward(password[w[0]:ends[k]])
+			if prev < 0 {
+				break
+			}
+			ends = append(ends, w[0]+prev)
+		}
+		for _, i := range starts {
+			for _, j := range ends {
+				token := [2]int{i, j}
+				if i >= j || seen[token] || utf8.RuneCountInString(password[i:j]) < minBreachedLength {
+					continue
+				}
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+// affixClass returns the class of the runs of characters dropped from tokens: 1 for
+// digits, 2 for symbols, or 0 for letters, which are kept.
+func affixClass(r rune) int {
+	switch {
+	case unicode.IsLetter(r) || unicode.IsMark(r):
+		return 0
+	case unicode.IsDigit(r):
+		return 1
+	}
+	return 2
+}
+
+// skipAffix returns the end of the run of digits or symbols starting at i in s, or -1
+// if there is none.
+func skipAffix(s string, i int) int {
+	r, size := utf8.DecodeRuneInString(s[i:])
+	class := affixClass(r)
+	if size == 0 || class == 0 {
+		return -1
+	}
+	for i < len(s) {
+		r, size = utf8.DecodeRuneInString(s[i:])
+		if affixClass(r) != class {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// skipAffixBackward returns the start of the run of digits or symbols ending s, or -1
+// if there is none.
+func skipAffixBackward(s string) int {
+	r, size := utf8.DecodeLastRuneInString(s)
+	class := affixClass(r)
+	if size == 0 || class == 0 {
+		return -1
+	}
+	j := len(s)
+	for j > 0 {
+		r, size = utf8.DecodeLastRuneInString(s[:j])
+		if affixClass(r) != class {
+			break
+		}
+		j -= size
+	}
+	return j
+}
@@ -0,0 +1,81 @@
+package matching
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akara-io/zxcvbn/breach"
+	"github.com/akara-io/zxcvbn/match"
+)
+
+func testBreachFilter() *breach.Filter {
+	b := breach.NewBuilder(1e-6)
+	b.AddPassword("Tr0ub4dour&3", 12)
+	b.AddPassword("ub4d", 5)
+	b.AddPassword("dour", 1500)
+	b.AddPassword("abc", 1000000)
+	b.AddPassword("sécurité", 3)
+	return b.Filter()
+}
+
+func Test_breachedMatch(t *testing.T) {
+	bm := breachedMatch{filter: testBreachFilter()}
+
+	assert.Equal(t, []*match.Match{
+		{Pattern: "breached", I: 0, J: 11, Token: "Tr0ub4dour&3", Rank: 3, Prevalence: 10},
+	}, bm.Matches("Tr0ub4dour&3"))
+
+	// runs of digits and symbols are dropped from the ends of the password and its words
+	assert.Equal(t, []*match.Match{
+		{Pattern: "breached", I: 0, J: 11, Token: "Tr0ub4dour&3", Rank: 3, Prevalence: 10},
+	}, bm.Matches("Tr0ub4dour&3!"))
+	assert.Equal(t, []*match.Match{
+		{Pattern: "breached", I: 4, J: 7, Token: "dour", Rank: 2, Prevalence: 1000},
+	}, bm.Matches("2024dour!!"))
+	assert.Equal(t, []*match.Match{
+		{Pattern: "breached", I: 3, J: 6, Token: "dour", Rank: 2, Prevalence: 1000},
+	}, bm.Matches("my dour day"))
+
+	// lookups are case sensitive, skip tokens shorter than 4 characters and other substrings
+	assert.Empty(t, bm.Matches("trUB4Dabc"))
+	assert.Empty(t, bm.Matches("abc!"))
+	assert.Empty(t, bm.Matches("xdourx"))
+
+	// indexes are in bytes, lengths in characters
+	assert.Equal(t, []*match.Match{
+		{Pattern: "breached", I: 2, J: 11, Token: "sécurité", Rank: 5, Prevalence: 1},
+	}, bm.Matches("a sécurité"))
+
+	assert.Empty(t, breachedMatch{}.Matches("Tr0ub4dour&3"))
+}
+
+func TestBreachedMatchFalsePositives(t *testing.T) {
+	b := breach.NewBuilder(1e-6)
+	for i := 0; i < 100000; i++ {
+		b.AddPassword(fmt.Sprintf("breached%d", i), i)
+	}
+	bm := breachedMatch{filter: b.Filter()}
+
+	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*()-_=+[]{};:,.<>/?"
+	rnd := rand.New(rand.NewSource(1))
+	password := make([]byte, 24)
+	for i := 0; i < 10000; i++ {
+		for k := range password {
+			password[k] = chars[rnd.Intn(len(chars))]
+		}
+		assert.Empty(t, bm.Matches(string(password)), string(password))
+	}
+}
+
+func TestOmnimatcherBreachFilter(t *testing.T) {
+	om := NewOmnimatcher(Config{BreachFilter: testBreachFilter()})
+	assert.Contains(t, om.Matches("Tr0ub4dour&3", nil), &match.Match{
+		Pattern: "breached", I: 0, J: 11, Token: "Tr0ub4dour&3", Rank: 3, Prevalence: 10,
+	})
+	for _, m := range Omnimatch("Tr0ub4dour&3", nil) {
+		assert.NotEqual(t, "breached", m.Pattern)
+	}
+}
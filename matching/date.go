@@ -1,7 +1,9 @@
 package matching
 
 import (
+	"context"
 	"strconv"
+	"time"
 
 	"github.com/dlclark/regexp2"
 
@@ -10,8 +12,13 @@ import (
 	"github.com/akara-io/zxcvbn/scoring"
 )
 
-const dateMaxYear = 2050
-const dateMinYear = 1000
+// Default limits of the years recognized in dates.
+const (
+	dateMaxYear = 2050
+	dateMinYear = 1000
+	// dateTwoDigitYearPivot is the last two-digit year read as 20xx: 50 is 2050, 51 is 1951.
+	dateTwoDigitYearPivot = 50
+)
 
 var dateSplits = map[int][]struct{ k, l int }{
 	4: { // for length-4 strings, eg 1191 or 9111, two ways to split:
@@ -51,8 +58,16 @@ var maybeDateWithSeparator = regexp2.MustCompile(
 //   a month between 1 and 12,
 //   a day between 1 and 31.
 //
-// note: this isn't true date parsing in that "feb 31st" is allowed,
-// this doesn't check for leap years, etc.
+// dates may also be written with month names, full or abbreviated, in any case:
+//   day-month(-year) like 15march1987 or 1st-jan-2000,
+//   month-day(-year) like Dec25 or dec-25th-1990,
+//   year-month-day like 1987-Feb-02, and month-year like jan1990,
+//   maybe preceded by a weekday when they include a day, like monday15mar,
+// and a weekday followed by a day is a date too, like friday13th.
+//
+// note: by default this isn't true date parsing in that "feb 31st" is allowed,
+// this doesn't check for leap years, etc. strict matching rejects those dates, and
+// weekdays that don't fall on the date they precede.
 //
 // recipe:
 // start with regex to find maybe-dates, then attempt to map the integers
@@ -69,13 +84,32 @@ type dateMatchCandidate struct {
 	Year  int
 }
 
-type dateMatch struct{}
+type dateMatch struct {
+	// referenceYear is the year candidates are compared to; zero means scoring.ReferenceYear.
+	referenceYear int
+	// names are the month and weekday names recognized; nil means English.
+	names *dateNameIndex
+	// minYear and maxYear bound the four-digit years; zero means dateMinYear and dateMaxYear.
+	minYear, maxYear int
+	// pivot is the last two-digit year read as 20xx; zero means dateTwoDigitYearPivot.
+	pivot int
+	// strict rejects the days that don't exist, like feb 29 outside leap years.
+	strict bool
+}
 
 func (dm dateMatch) Matches(password string) []*match.Match {
+	matches, _ := dm.matchesContext(context.Background(), password)
+	return matches
+}
+
+func (dm dateMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
 	matches := []*match.Match{}
 
 	// dates without separators are between length 4 '1191' and 8 '11111991'
 	for i := 0; i <= len(password)-4; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		for j := i + 3; j <= i+7; j++ {
 			if j >= len(password) {
 				break
@@ -87,7 +121,7 @@ func (dm dateMatch) Matches(password string) []*match.Match {
 			var candidates []*dateMatchCandidate
 			for _, s := range dateSplits[len(token)] {
 				s1, s2, s3 := token[0:s.k], token[s.k:s.l], token[s.l:]
-				if dmy := mapIntsToDMY(s1, s2, s3); dmy != nil {
+				if dmy := dm.mapIntsToDMY(s1, s2, s3); dmy != nil {
 					candidates = append(candidates, dmy)
 				}
 			}
@@ -101,9 +135,9 @@ func (dm dateMatch) Matches(password string) []*match.Match {
 			// ie, considering '111504', prefer 11-15-04 to 1-1-1504
 			// (interpreting '04' as 2004)
 			bestCandidate := candidates[0]
-			minDistance := dateMatchMetric(candidates[0])
+			minDistance := dm.metric(candidates[0])
 			for _, candidate := range candidates[1:] {
-				distance := dateMatchMetric(candidate)
+				distance := dm.metric(candidate)
 				if distance < minDistance {
 					bestCandidate = candidate
 					minDistance = distance
@@ -125,6 +159,9 @@ func (dm dateMatch) Matches(password string) []*match.Match {
 
 	// dates with separators are between length 6 '1/1/91' and 10 '11/11/1991'
 	for i := 0; i <= len(password)-6; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		for j := i + 5; j <= i+9; j++ {
 			if j >= len(password) {
 				break
@@ -135,7 +172,7 @@ func (dm dateMatch) Matches(password string) []*match.Match {
 				continue
 			}
 
-			dmy := mapIntsToDMY(
+			dmy := dm.mapIntsToDMY(
 				m.GroupByNumber(1).String(),
 				m.GroupByNumber(3).String(),
 				m.GroupByNumber(4).String(),
@@ -155,6 +192,12 @@ func (dm dateMatch) Matches(password string) []*match.Match {
 		}
 	}
 
+	named, err := dm.namedDateMatches(ctx, password)
+	if err != nil {
+		return nil, err
+	}
+	matches = append(matches, named...)
+
 	// matches now contains all valid date strings in a way that is tricky to capture
 	// with regexes only. while thorough, it will contain some unintuitive noise:
 	//
@@ -179,14 +222,18 @@ func (dm dateMatch) Matches(password string) []*match.Match {
 		}
 	}
 	match.Sort(filteredMatches)
-	return filteredMatches
+	return filteredMatches, nil
 }
 
-func dateMatchMetric(c *dateMatchCandidate) int {
-	return mathutils.Abs(c.Year - scoring.ReferenceYear)
+func (dm dateMatch) metric(c *dateMatchCandidate) int {
+	referenceYear := dm.referenceYear
+	if referenceYear == 0 {
+		referenceYear = scoring.ReferenceYear
+	}
+	return mathutils.Abs(c.Year - referenceYear)
 }
 
-func mapIntsToDMY(s1, s2, s3 string) *dateMatchCandidate {
+func (dm dateMatch) mapIntsToDMY(s1, s2, s3 string) *dateMatchCandidate {
 	// given a 3-tuple, discard if:
 	//   middle int is over 31 (for all dmy formats, years are never allowed in the middle)
 	//   middle int is zero
@@ -201,11 +248,12 @@ func mapIntsToDMY(s1, s2, s3 string) *dateMatchCandidate {
 	if i2 > 31 || i2 <= 0 {
 		return nil
 	}
+	minYear, maxYear := dm.yearRange()
 	over12 := 0
 	over31 := 0
 	under1 := 0
 	for _, i := range [3]int{i1, i2, i3} {
-		if (i > 99 && i < dateMinYear) || i > dateMaxYear {
+		if (i > 99 && i < minYear) || i > maxYear {
 			return nil
 		}
 		if i > 31 {
@@ -229,36 +277,33 @@ func mapIntsToDMY(s1, s2, s3 string) *dateMatchCandidate {
 	}
 	for _, split := range possibleYearSplits {
 		y := split[0]
-		if dateMinYear <= y && y <= dateMaxYear {
+		if minYear <= y && y <= maxYear {
 			// for a candidate that includes a four-digit year,
 			// when the remaining ints don't match to a day and month,
 			// it is not a date.
-			return mapIntsToDM(split[1], split[2], y)
+			return dm.mapIntsToDM(split[1], split[2], y)
 		}
 	}
 
 	// given no four-digit year, two digit years are the most flexible int to match, so
 	// try to parse a day-month out of ints[0..1] or ints[1..0]
 	for _, split := range possibleYearSplits {
-		y := split[0]
-		dm := mapIntsToDM(split[1], split[2], y)
-		if dm != nil {
-			dm.Year = twoToFourDigitYear(dm.Year)
+		if dm := dm.mapIntsToDM(split[1], split[2], dm.fourDigitYear(split[0])); dm != nil {
 			return dm
 		}
 	}
 	return nil
 }
 
-func mapIntsToDM(i1, i2 int, year int) *dateMatchCandidate {
-	if i1 <= 31 && i2 <= 12 {
+func (dm dateMatch) mapIntsToDM(i1, i2 int, year int) *dateMatchCandidate {
+	if i1 <= 31 && i2 <= 12 && dm.validDay(i1, i2, year) {
 		return &dateMatchCandidate{
 			Day:   i1,
 			Month: i2,
 			Year:  year,
 		}
 	}
-	if i2 <= 31 && i1 <= 12 {
+	if i2 <= 31 && i1 <= 12 && dm.validDay(i2, i1, year) {
 		return &dateMatchCandidate{
 			Day:   i2,
 			Month: i1,
@@ -268,10 +313,57 @@ func mapIntsToDM(i1, i2 int, year int) *dateMatchCandidate {
 	return nil
 }
 
-func twoToFourDigitYear(year int) int {
+// validDay reports whether the month has the day in year. Without strict matching, any day
+// up to 31 is valid. A zero year is any year, so february 29 is valid then.
+func (dm dateMatch) validDay(day, month, year int) bool {
+	if !dm.strict || day == 0 {
+		return true
+	}
+	if year == 0 {
+		year = 2000
+	}
+	return day <= daysIn(time.Month(month), year)
+}
+
+// validWeekday reports whether the date falls on weekday, from 1 for monday to 7 for sunday.
+// Without strict matching or a full date, any weekday is valid.
+func (dm dateMatch) validWeekday(weekday, day, month, year int) bool {
+	if !dm.strict || day == 0 || month == 0 || year == 0 {
+		return true
+	}
+	wd := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).Weekday()
+	return int(wd) == weekday%7
+}
+
+func daysIn(month time.Month, year int) int {
+	// day 0 of the next month is the last day of month
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func (dm dateMatch) yearRange() (minYear, maxYear int) {
+	minYear, maxYear = dm.minYear, dm.maxYear
+	if minYear == 0 {
+		minYear = dateMinYear
+	}
+	if maxYear == 0 {
+		maxYear = dateMaxYear
+	}
+	return minYear, maxYear
+}
+
+// fourDigitYear reads two-digit years around the pivot, 87 being 1987 and 15 2015.
+func (dm dateMatch) fourDigitYear(year int) int {
+	pivot := dm.pivot
+	if pivot == 0 {
+		pivot = dateTwoDigitYearPivot
+	}
+	return twoToFourDigitYear(year, pivot)
+}
+
+func twoToFourDigitYear(year, pivot int) int {
 	if year > 99 {
 		return year
-	} else if year > 50 {
+	} else if year > pivot {
 		// 87 -> 1987
 		return year + 1900
 	} else {
@@ -0,0 +1,437 @@
+package matching
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/akara-io/zxcvbn/match"
+	"github.com/akara-io/zxcvbn/scoring"
+)
+
+// DateNames are the names of months and weekdays recognized in dates, in one language.
+// Names are matched regardless of case.
+type DateNames struct {
+	// Months lists the names of each month from January, full and abbreviated.
+	Months [12][]string
+	// Weekdays lists the names of each day of the week from Monday, full and abbreviated.
+	Weekdays [7][]string
+	// Ordinals are the suffixes of ordinal days, like the "nd" of 22nd.
+	Ordinals []string
+}
+
+// EnglishDateNames are the English month and weekday names, matched by default.
+var EnglishDateNames = DateNames{
+	Months: [12][]string{
+		{"january", "jan"},
+		{"february", "feb"},
+		{"march", "mar"},
+		{"april", "apr"},
+		{"may"},
+		{"june", "jun"},
+		{"july", "jul"},
+		{"august", "aug"},
+		{"september", "sept", "sep"},
+		{"october", "oct"},
+		{"november", "nov"},
+		{"december", "dec"},
+	},
+	Weekdays: [7][]string{
+		{"monday", "mon"},
+		{"tuesday", "tues", "tue"},
+		{"wednesday", "wed"},
+		{"thursday", "thurs", "thu"},
+		{"friday", "fri"},
+		{"saturday", "sat"},
+		{"sunday", "sun"},
+	},
+	Ordinals: []string{"st", "nd", "rd", "th"},
+}
+
+// FrenchDateNames are the French month and weekday names.
+var FrenchDateNames = DateNames{
+	Months: [12][]string{
+		{"janvier", "janv"},
+		{"février", "fevrier", "févr", "fevr"},
+		{"mars"},
+		{"avril", "avr"},
+		{"mai"},
+		{"juin"},
+		{"juillet", "juil"},
+		{"août", "aout"},
+		{"septembre", "sept"},
+		{"octobre", "oct"},
+		{"novembre", "nov"},
+		{"décembre", "decembre", "déc", "dec"},
+	},
+	Weekdays: [7][]string{
+		{"lundi", "lun"},
+		{"mardi", "mar"},
+		{"mercredi", "mer"},
+		{"jeudi", "jeu"},
+		{"vendredi", "ven"},
+		{"samedi", "sam"},
+		{"dimanche", "dim"},
+	},
+	Ordinals: []string{"er"},
+}
+
+// GermanDateNames are the German month and weekday names.
+var GermanDateNames = DateNames{
+	Months: [12][]string{
+		{"januar", "jan"},
+		{"februar", "feb"},
+		{"märz", "maerz", "mär"},
+		{"april", "apr"},
+		{"mai"},
+		{"juni", "jun"},
+		{"juli", "jul"},
+		{"august", "aug"},
+		{"september", "sept", "sep"},
+		{"oktober", "okt"},
+		{"november", "nov"},
+		{"dezember", "dez"},
+	},
+	Weekdays: [7][]string{
+		{"montag", "mo"},
+		{"dienstag", "di"},
+		{"mittwoch", "mi"},
+		{"donnerstag", "do"},
+		{"freitag", "fr"},
+		{"samstag", "sa"},
+		{"sonntag", "so"},
+	},
+	Ordinals: []string{"."},
+}
+
+// dateName is a month or weekday name, with its number from 1.
+type dateName struct {
+	name  string
+	value int
+}
+
+// dateNameIndex holds the lowercase names of several languages.
+type dateNameIndex struct {
+	langs    []DateNames
+	months   []dateName
+	weekdays []dateName
+	ordinals []string
+}
+
+func newDateNameIndex(langs []DateNames) *dateNameIndex {
+	idx := &dateNameIndex{langs: append([]DateNames(nil), langs...)}
+	seen := make(map[string]bool)
+	add := func(names []dateName, name string, value int) []dateName {
+		name = strings.ToLower(name)
+		if name == "" || seen[name] {
+			return names
+		}
+		seen[name] = true
+		return append(names, dateName{name, value})
+	}
+	for _, lang := range langs {
+		for m, names := range lang.Months {
+			for _, name := range names {
+				idx.months = add(idx.months, name, m+1)
+			}
+		}
+	}
+	seen = make(map[string]bool)
+	for _, lang := range langs {
+		for d, names := range lang.Weekdays {
+			for _, name := range names {
+				idx.weekdays = add(idx.weekdays, name, d+1)
+			}
+		}
+	}
+	ordinals := make(map[string]bool)
+	for _, lang := range langs {
+		for _, o := range lang.Ordinals {
+			if o = strings.ToLower(o); o != "" && !ordinals[o] {
+				ordinals[o] = true
+				idx.ordinals = append(idx.ordinals, o)
+			}
+		}
+	}
+	return idx
+}
+
+// hasPrefixFold reports whether s begins with the lowercase prefix, regardless of case.
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// hasSuffixFold reports whether s ends with the lowercase suffix, regardless of case.
+func hasSuffixFold(s, suffix string) bool {
+	return len(s) >= len(suffix) && strings.EqualFold(s[len(s)-len(suffix):], suffix)
+}
+
+var defaultDateNames = newDateNameIndex([]DateNames{EnglishDateNames})
+
+// isDateSeparator reports whether b may separate the parts of a date, like in maybeDateWithSeparator.
+func isDateSeparator(b byte) bool {
+	return strings.IndexByte(" \t\n\v\f\r/\\_.-", b) >= 0
+}
+
+// datePart is a day and/or a year written in digits next to a month or weekday name.
+type datePart struct {
+	// pos is the start of a part written before the name, the end of a part written after it.
+	pos int
+	// sep separates the part from the name, and the day from the year.
+	sep     string
+	day     int
+	year    int
+	ordinal bool
+}
+
+// namedDateMatches returns the dates written with a month name, like jan1990, 15march1987,
+// Dec25 or 1987-Feb-02, maybe preceded by a weekday, and the weekdays followed by a day,
+// like friday13th. Of the interpretations of a token, the one taking the fewest guesses
+// is kept.
+func (dm dateMatch) namedDateMatches(ctx context.Context, password string) ([]*match.Match, error) {
+	idx := dm.names
+	if idx == nil {
+		idx = defaultDateNames
+	}
+	scorer := scoring.Scorer{ReferenceYear: dm.referenceYear}
+	best := make(map[[2]int]*match.Match)
+	add := func(m *match.Match) {
+		key := [2]int{m.I, m.J}
+		if old, ok := best[key]; ok && scorer.DateGuesses(old) <= scorer.DateGuesses(m) {
+			return
+		}
+		best[key] = m
+	}
+	addWithWeekdays := func(m *match.Match) {
+		add(m)
+		if m.Day == 0 || m.Weekday != "" {
+			return
+		}
+		for _, sep := range separatorsBefore(password, m.I) {
+			end := m.I - len(sep)
+			for _, wd := range idx.weekdays {
+				if hasSuffixFold(password[:end], wd.name) && dm.validWeekday(wd.value, m.Day, m.Month, m.Year) {
+					w := *m
+					w.I = end - len(wd.name)
+					w.Token = password[w.I : w.J+1]
+					w.Weekday = password[w.I:end]
+					add(&w)
+				}
+			}
+		}
+	}
+
+	for ms := range password {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, month := range idx.months {
+			if !hasPrefixFold(password[ms:], month.name) {
+				continue
+			}
+			me := ms + len(month.name)
+			rights := dm.partsAfter(idx, password, me, true)
+			for _, l := range dm.partsBefore(idx, password, ms) {
+				for _, r := range rights {
+					if !combinable(l, r, ms, me) || !dm.validDay(l.day+r.day, month.value, l.year+r.year) {
+						continue
+					}
+					sep := r.sep
+					if l.pos < ms {
+						sep = l.sep
+					}
+					addWithWeekdays(&match.Match{
+						Pattern:   "date",
+						Token:     password[l.pos:r.pos],
+						I:         l.pos,
+						J:         r.pos - 1,
+						Separator: sep,
+						Year:      l.year + r.year,
+						Month:     month.value,
+						Day:       l.day + r.day,
+						MonthName: password[ms:me],
+						Ordinal:   l.ordinal || r.ordinal,
+					})
+				}
+			}
+		}
+		for _, wd := range idx.weekdays {
+			if !hasPrefixFold(password[ms:], wd.name) {
+				continue
+			}
+			we := ms + len(wd.name)
+			for _, r := range dm.partsAfter(idx, password, we, false) {
+				if r.day == 0 {
+					continue
+				}
+				add(&match.Match{
+					Pattern:   "date",
+					Token:     password[ms:r.pos],
+					I:         ms,
+					J:         r.pos - 1,
+					Separator: r.sep,
+					Day:       r.day,
+					Weekday:   password[ms:we],
+					Ordinal:   r.ordinal,
+				})
+			}
+		}
+	}
+
+	matches := make([]*match.Match, 0, len(best))
+	for _, m := range best {
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+// combinable reports whether l and r, the parts before and after the month name at
+// [ms, me), make a date: day-month(-year), (year-)month-day, year-month-day or month-year,
+// all parts being separated the same way.
+func combinable(l, r datePart, ms, me int) bool {
+	if l.pos < ms && r.pos > me && l.sep != r.sep {
+		return false
+	}
+	switch {
+	case l.year != 0:
+		return r.day != 0 && r.year == 0
+	case l.day != 0:
+		return r.day == 0
+	default:
+		return r.day != 0 || r.year != 0
+	}
+}
+
+// partsBefore returns the parts that may be written before the name starting at end: none,
+// a day maybe followed by an ordinal suffix, or a four-digit year.
+func (dm dateMatch) partsBefore(idx *dateNameIndex, s string, end int) []datePart {
+	parts := []datePart{{pos: end}}
+	for _, sep := range separatorsBefore(s, end) {
+		numEnd := end - len(sep)
+		for _, o := range idx.ordinalSuffixes() {
+			if !hasSuffixFold(s[:numEnd], o) {
+				continue
+			}
+			dayEnd := numEnd - len(o)
+			for _, start := range digitsBefore(s, dayEnd, 1, 2) {
+				if day, ok := parseDateDay(s[start:dayEnd]); ok {
+					parts = append(parts, datePart{pos: start, sep: sep, day: day, ordinal: o != ""})
+				}
+			}
+		}
+		for _, start := range digitsBefore(s, numEnd, 4, 4) {
+			if year, ok := dm.parseYear(s[start:numEnd]); ok {
+				parts = append(parts, datePart{pos: start, sep: sep, year: year})
+			}
+		}
+	}
+	return parts
+}
+
+// partsAfter returns the parts that may be written after the name ending at start: none,
+// a day maybe followed by an ordinal suffix and, if withYear, by a year, or a year alone.
+func (dm dateMatch) partsAfter(idx *dateNameIndex, s string, start int, withYear bool) []datePart {
+	parts := []datePart{{pos: start}}
+	for _, sep := range separatorsAfter(s, start) {
+		numStart := start + len(sep)
+		for _, end := range digitsAfter(s, numStart, 1, 2) {
+			day, ok := parseDateDay(s[numStart:end])
+			if !ok {
+				continue
+			}
+			for _, o := range idx.ordinalSuffixes() {
+				if !hasPrefixFold(s[end:], o) {
+					continue
+				}
+				dayEnd := end + len(o)
+				parts = append(parts, datePart{pos: dayEnd, sep: sep, day: day, ordinal: o != ""})
+				if !withYear || !strings.HasPrefix(s[dayEnd:], sep) {
+					continue
+				}
+				yearStart := dayEnd + len(sep)
+				for _, yearEnd := range digitsAfter(s, yearStart, 2, 4) {
+					if year, ok := dm.parseYear(s[yearStart:yearEnd]); ok {
+						parts = append(parts, datePart{pos: yearEnd, sep: sep, day: day, year: year, ordinal: o != ""})
+					}
+				}
+			}
+		}
+		if !withYear {
+			continue
+		}
+		for _, end := range digitsAfter(s, numStart, 2, 4) {
+			if year, ok := dm.parseYear(s[numStart:end]); ok {
+				parts = append(parts, datePart{pos: end, sep: sep, year: year})
+			}
+		}
+	}
+	return parts
+}
+
+// ordinalSuffixes returns the ordinal suffixes, preceded by the empty suffix.
+func (idx *dateNameIndex) ordinalSuffixes() []string {
+	return append([]string{""}, idx.ordinals...)
+}
+
+// separatorsBefore returns the separators that may end at end: none, or the character before.
+func separatorsBefore(s string, end int) []string {
+	if end > 0 && isDateSeparator(s[end-1]) {
+		return []string{"", s[end-1 : end]}
+	}
+	return []string{""}
+}
+
+// separatorsAfter returns the separators that may start at start: none, or the character at start.
+func separatorsAfter(s string, start int) []string {
+	if start < len(s) && isDateSeparator(s[start]) {
+		return []string{"", s[start : start+1]}
+	}
+	return []string{""}
+}
+
+// digitsBefore returns the starts of the runs of min to max digits ending at end.
+func digitsBefore(s string, end, min, max int) []int {
+	var starts []int
+	for n := 1; n <= max && end-n >= 0 && isDigit(s[end-n]); n++ {
+		if n >= min {
+			starts = append(starts, end-n)
+		}
+	}
+	return starts
+}
+
+// digitsAfter returns the ends of the runs of min to max digits starting at start.
+func digitsAfter(s string, start, min, max int) []int {
+	var ends []int
+	for n := 1; n <= max && start+n <= len(s) && isDigit(s[start+n-1]); n++ {
+		if n >= min {
+			ends = append(ends, start+n)
+		}
+	}
+	return ends
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+func parseDateDay(s string) (int, bool) {
+	day, err := strconv.Atoi(s)
+	return day, err == nil && 1 <= day && day <= 31
+}
+
+// parseYear parses a two-digit year, or a four-digit one within the year range.
+func (dm dateMatch) parseYear(s string) (int, bool) {
+	year, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+	switch len(s) {
+	case 2:
+		return dm.fourDigitYear(year), true
+	case 4:
+		minYear, maxYear := dm.yearRange()
+		return year, minYear <= year && year <= maxYear
+	}
+	return 0, false
+}
@@ -200,14 +200,155 @@ func Test_dateMatch(t *testing.T) {
 
 func Test_twoToFourDigitYear(t *testing.T) {
 	tests := []struct {
-		year int
-		want int
+		year  int
+		pivot int
+		want  int
 	}{
-		{60, 1960},
-		{960, 960},
-		{20, 2020},
+		{60, 50, 1960},
+		{960, 50, 960},
+		{20, 50, 2020},
+		{50, 50, 2050},
+		{51, 50, 1951},
+		{60, 70, 2060},
+		{40, 30, 1940},
 	}
 	for _, tt := range tests {
-		assert.Equal(t, tt.want, twoToFourDigitYear(tt.year))
+		assert.Equal(t, tt.want, twoToFourDigitYear(tt.year, tt.pivot))
+	}
+	// the pivot defaults to 50
+	assert.Equal(t, 1951, dateMatch{}.fourDigitYear(51))
+	assert.Equal(t, 2060, dateMatch{pivot: 70}.fourDigitYear(60))
+}
+
+func Test_dateMatchStrict(t *testing.T) {
+	lenient, strict := dateMatch{}, dateMatch{strict: true}
+
+	// impossible days are only dates when matching leniently
+	for _, password := range []string{"31/02/1990", "30.2.2000", "31-04-85", "29/2/2001", "feb30th", "31april1990", "1990-jun-31"} {
+		assert.Len(t, lenient.Matches(password), 1, password)
+		for _, m := range strict.Matches(password) {
+			assert.NotEqual(t, password, m.Token, password)
+		}
+	}
+	// leap years are
+	for _, password := range []string{"29/02/2000", "29.2.1996", "29feb2024", "feb29", "31/12/1999", "30-4-85"} {
+		matches := strict.Matches(password)
+		if assert.Len(t, matches, 1, password) {
+			assert.Equal(t, password, matches[0].Token)
+		}
+	}
+	// when a date is ambiguous, a reading that exists is kept: 1990 2 31 isn't one, 1990 23 1 is
+	dmy := func(m *match.Match) [3]int { return [3]int{m.Day, m.Month, m.Year} }
+	assert.Equal(t, [3]int{31, 2, 1990}, dmy(lenient.Matches("1990231")[0]))
+	assert.Equal(t, [3]int{23, 1, 1990}, dmy(strict.Matches("1990231")[0]))
+
+	// weekdays must fall on the date: march 15th 1999 was a monday
+	assert.Equal(t, "monday", strict.Matches("monday15mar1999")[0].Weekday)
+	for _, m := range strict.Matches("friday15mar1999") {
+		assert.NotEqual(t, "friday15mar1999", m.Token)
+	}
+	assert.Equal(t, "friday15mar1999", lenient.Matches("friday15mar1999")[0].Token)
+	// sundays are the last weekday
+	assert.Equal(t, "sun", strict.Matches("sun1jan2023")[0].Weekday)
+}
+
+func Test_dateMatchYears(t *testing.T) {
+	// four-digit years out of the range aren't dates
+	whole := func(dm dateMatch, password string) *match.Match {
+		for _, m := range dm.Matches(password) {
+			if m.Token == password {
+				return m
+			}
+		}
+		return nil
+	}
+	assert.Nil(t, whole(dateMatch{}, "1/1/2060"))
+	if m := whole(dateMatch{maxYear: 2100}, "1/1/2060"); assert.NotNil(t, m) {
+		assert.Equal(t, 2060, m.Year)
+	}
+	assert.NotNil(t, whole(dateMatch{}, "1/1/1200"))
+	assert.Nil(t, whole(dateMatch{minYear: 1900}, "1/1/1200"))
+	assert.NotNil(t, whole(dateMatch{}, "jan-1200"))
+	assert.Nil(t, whole(dateMatch{minYear: 1900}, "jan-1200"))
+
+	// two-digit years are read around the pivot
+	assert.Equal(t, 1960, dateMatch{}.Matches("1/1/60")[0].Year)
+	assert.Equal(t, 2060, dateMatch{pivot: 70}.Matches("1/1/60")[0].Year)
+	assert.Equal(t, 2060, dateMatch{pivot: 70}.Matches("jan-60")[0].Year)
+}
+
+func Test_dateMatchNames(t *testing.T) {
+	dm := dateMatch{referenceYear: 2017}
+	for _, tt := range []struct {
+		password string
+		want     match.Match
+	}{
+		{"jan1990", match.Match{Year: 1990, Month: 1, MonthName: "jan"}},
+		{"15march1987", match.Match{Year: 1987, Month: 3, Day: 15, MonthName: "march"}},
+		{"MAY-87", match.Match{Year: 1987, Month: 5, MonthName: "MAY", Separator: "-"}},
+		{"dec5th", match.Match{Month: 12, Day: 5, MonthName: "dec", Ordinal: true}},
+		{"1987-Feb-02", match.Match{Year: 1987, Month: 2, Day: 2, MonthName: "Feb", Separator: "-"}},
+		{"1st-jan-2000", match.Match{Year: 2000, Month: 1, Day: 1, MonthName: "jan", Separator: "-", Ordinal: true}},
+		{"22ndOctober", match.Match{Month: 10, Day: 22, MonthName: "October", Ordinal: true}},
+		{"Sept 3 1975", match.Match{Year: 1975, Month: 9, Day: 3, MonthName: "Sept", Separator: " "}},
+		{"friday13th", match.Match{Day: 13, Weekday: "friday", Ordinal: true}},
+		{"monday15mar1999", match.Match{Year: 1999, Month: 3, Day: 15, MonthName: "mar", Weekday: "monday"}},
+		{"Wed-dec-3", match.Match{Month: 12, Day: 3, MonthName: "dec", Separator: "-", Weekday: "Wed"}},
+	} {
+		t.Run(tt.password, func(t *testing.T) {
+			want := tt.want
+			want.Pattern = "date"
+			want.Token = tt.password
+			want.J = len(tt.password) - 1
+			assert.Equal(t, []*match.Match{&want}, dm.Matches(tt.password))
+		})
+	}
+
+	// month names are found within passwords
+	matches := dm.Matches("xx14feb2012!")
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, "14feb2012", matches[0].Token)
+		assert.Equal(t, 2, matches[0].I)
+	}
+
+	// of the ways to read a token, the one taking the fewest guesses is kept:
+	// dec 2025 rather than december 25th
+	matches = dm.Matches("Dec25")
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, 2025, matches[0].Year)
+		assert.Equal(t, 0, matches[0].Day)
+	}
+
+	// names alone, names without valid days or years and mixed separators aren't dates
+	for _, password := range []string{"march", "monday", "mar0", "feb99x", "friday1990", "15-mar/1987"} {
+		for _, m := range dm.Matches(password) {
+			assert.NotEqual(t, password, m.Token)
+		}
+	}
+
+	// other languages
+	dm.names = newDateNameIndex([]DateNames{FrenchDateNames, GermanDateNames})
+	for _, tt := range []struct {
+		password          string
+		day, month, year  int
+		weekday, ordinals bool
+	}{
+		{"14juillet1789", 14, 7, 1789, false, false},
+		{"1erjanvier", 1, 1, 0, false, true},
+		{"lundi3février", 3, 2, 0, true, false},
+		{"3.März", 3, 3, 0, false, true},
+		{"Dezember2001", 0, 12, 2001, false, false},
+	} {
+		matches := dm.Matches(tt.password)
+		if assert.Len(t, matches, 1, tt.password) {
+			m := matches[0]
+			assert.Equal(t, tt.password, m.Token)
+			assert.Equal(t, [3]int{tt.day, tt.month, tt.year}, [3]int{m.Day, m.Month, m.Year}, tt.password)
+			assert.Equal(t, tt.weekday, m.Weekday != "", tt.password)
+			assert.Equal(t, tt.ordinals, m.Ordinal, tt.password)
+		}
+	}
+	for _, m := range dm.Matches("march1990") {
+		assert.Empty(t, m.MonthName, "English is replaced")
 	}
 }
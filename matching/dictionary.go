@@ -1,60 +1,266 @@
 package matching
 
 import (
+	"context"
+	"reflect"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/akara-io/zxcvbn/frequency/packed"
 	"github.com/akara-io/zxcvbn/match"
 )
 
 type dictionaryMatch struct {
-	rankedDictionaries map[string]rankedDictionnary
+	rankedDictionaries map[string]Dictionary
+	// indexes hold the words of rankedDictionaries, each dictionary being enabled in a single index.
+	indexes []dictionaryIndex
+	// normalization is the form passwords are put in before being matched.
+	normalization Normalization
+	// foldDiacritics matches letters regardless of their diacritics, like cafe with café.
+	foldDiacritics bool
+}
+
+// wordIndex finds the words of several dictionaries that prefix a string.
+type wordIndex interface {
+	// names returns the names of the dictionaries of the index.
+	names() []string
+	// walk calls fn for every word of the index that is a prefix of s, lowercased.
+	// end is the length in bytes of the prefix of s matching the word.
+	walk(s string, fn func(end int, word string, entries []indexEntry))
+	// root returns the position of the empty prefix, from which the words of the index
+	// are walked one rune at a time with next.
+	root() wordPos
+	// next returns the position of the prefix at p followed by the lowercase r, or false
+	// if no word starts with it.
+	next(p wordPos, r rune) (wordPos, bool)
+	// wordEntries returns the entries of the word spelled by the prefix at p, if any.
+	wordEntries(p wordPos) []indexEntry
+}
+
+// wordPos is the position of a prefix in a wordIndex.
+type wordPos struct {
+	node int32        // in a trie
+	rng  packed.Range // in a packedIndex
+}
+
+type indexEntry struct {
+	dict int32 // index in names()
+	rank int32
+}
+
+// dictionaryIndex is a wordIndex, possibly shared with other dictionaryMatch values,
+// of which only the enabled dictionaries are matched against.
+type dictionaryIndex struct {
+	index   wordIndex
+	enabled []bool // indexed like index.names()
+}
+
+func newDictionaryMatch(rankedDictionaries map[string]Dictionary) dictionaryMatch {
+	return dictionaryMatch{}.with(rankedDictionaries)
+}
+
+// with returns a dictionaryMatch matching against rankedDictionaries.
+// The indexes of dm are reused for the dictionaries found in both, so that only
+// new dictionaries need to be indexed. Lists of a packed.Dict are matched in place.
+func (dm dictionaryMatch) with(rankedDictionaries map[string]Dictionary) dictionaryMatch {
+	res := dictionaryMatch{
+		rankedDictionaries: rankedDictionaries,
+		normalization:      dm.normalization,
+		foldDiacritics:     dm.foldDiacritics,
+	}
+	indexed := make(map[string]bool)
+	for _, idx := range dm.indexes {
+		names := idx.index.names()
+		enabled := make([]bool, len(names))
+		found := false
+		for i, name := range names {
+			d, ok := rankedDictionaries[name]
+			if ok && idx.enabled[i] && sameDictionary(d, dm.rankedDictionaries[name]) {
+				enabled[i] = true
+				indexed[name] = true
+				found = true
+			}
+		}
+		if found {
+			res.indexes = append(res.indexes, dictionaryIndex{index: idx.index, enabled: enabled})
+		}
+	}
+	missing := make(map[string]Dictionary)
+	packedLists := make(map[*packed.Dict][]bool)
+	for name, d := range rankedDictionaries {
+		if indexed[name] {
+			continue
+		}
+		if pl, ok := d.(packedList); ok && pl.name == name {
+			if packedLists[pl.d] == nil {
+				packedLists[pl.d] = make([]bool, len(pl.d.Names()))
+			}
+			packedLists[pl.d][pl.list] = true
+			continue
+		}
+		missing[name] = d
+	}
+	for d, enabled := range packedLists {
+		res.indexes = append(res.indexes, dictionaryIndex{index: newPackedIndex(d), enabled: enabled})
+	}
+	if len(missing) > 0 {
+		t := newTrie(missing)
+		enabled := make([]bool, len(t.names()))
+		for i := range enabled {
+			enabled[i] = true
+		}
+		res.indexes = append(res.indexes, dictionaryIndex{index: t, enabled: enabled})
+	}
+	return res
+}
+
+func sameDictionary(a, b Dictionary) bool {
+	if a, ok := a.(RankedDictionary); ok {
+		b, ok := b.(RankedDictionary)
+		return ok && reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+	}
+	return reflect.TypeOf(a).Comparable() && a == b
 }
 
 func (dm dictionaryMatch) Matches(password string) []*match.Match {
+	results, _ := dm.matchesContext(context.Background(), password)
+	return results
+}
+
+func (dm dictionaryMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
 	var results []*match.Match
 
-	for dictionaryName, rankedDict := range dm.rankedDictionaries {
-		for i := range password {
-			j := len(password) - 1
-			for delta := range password[i:] {
-				if delta > 0 {
-					j = i + delta - 1
+	t := normalize(password, dm.normalization.form())
+	var alts [][]rune
+	if dm.foldDiacritics {
+		alts = make([][]rune, len(t.text))
+		for i, r := range t.text {
+			alts[i] = diacriticAlternatives(unicode.ToLower(r))
+		}
+	}
+
+	for i := range t.text {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !t.boundary(i) {
+			continue
+		}
+		for _, idx := range dm.indexes {
+			add := func(end int, word string, entries []indexEntry, diacritics int) {
+				if !t.boundary(end) {
+					return
 				}
-				word := strings.ToLower(password[i : j+1])
-				if val, ok := rankedDict[word]; ok {
-					matchDic := &match.Match{
+				start, stop := t.span(i, end-1)
+				for _, e := range entries {
+					if !idx.enabled[e.dict] {
+						continue
+					}
+					results = append(results, &match.Match{
 						Pattern:        "dictionary",
-						I:              i,
-						J:              j,
-						Token:          password[i : j+1],
+						I:              start,
+						J:              stop - 1,
+						Token:          password[start:stop],
 						MatchedWord:    word,
-						Rank:           val,
-						DictionaryName: dictionaryName,
-					}
-
-					results = append(results, matchDic)
+						Rank:           int(e.rank),
+						DictionaryName: idx.index.names()[e.dict],
+						Diacritics:     diacritics,
+					})
 				}
 			}
+			if alts == nil {
+				idx.index.walk(t.text[i:], func(end int, word string, entries []indexEntry) {
+					add(i+end, word, entries, 0)
+				})
+			} else {
+				w := foldWalk{index: idx.index, text: t.text, alts: alts, add: add}
+				w.walk(idx.index.root(), i, 0)
+			}
 		}
 	}
 
 	match.Sort(results)
-	return results
+	return results, nil
+}
+
+// foldWalk finds the words of an index spelled by a text regardless of diacritics, each
+// letter being read as any of its alternatives.
+type foldWalk struct {
+	index wordIndex
+	text  string
+	alts  [][]rune // the alternatives of the letter at each byte offset of text
+	word  []byte
+	// add is called with the end of each word found in text, the word, its entries and
+	// the number of its letters having other diacritics in text.
+	add func(end int, word string, entries []indexEntry, diacritics int)
+}
+
+func (w *foldWalk) walk(p wordPos, pos, diacritics int) {
+	if pos == len(w.text) {
+		return
+	}
+	_, size := utf8.DecodeRuneInString(w.text[pos:])
+	for k, r := range w.alts[pos] {
+		next, ok := w.index.next(p, r)
+		if !ok {
+			continue
+		}
+		d := diacritics
+		if k > 0 {
+			d++ // the first alternative is the letter itself
+		}
+		n := len(w.word)
+		w.word = utf8.AppendRune(w.word, r)
+		if entries := w.index.wordEntries(next); len(entries) > 0 {
+			w.add(pos+size, string(w.word), entries, d)
+		}
+		w.walk(next, pos+size, d)
+		w.word = w.word[:n]
+	}
 }
 
-func (dm dictionaryMatch) withDict(name string, d rankedDictionnary) dictionaryMatch {
-	rd2 := make(map[string]rankedDictionnary, len(dm.rankedDictionaries)+1)
+// withDict returns a copy of dm also matching against d, replacing any dictionary with the same name.
+func (dm dictionaryMatch) withDict(name string, d Dictionary) dictionaryMatch {
+	rd2 := make(map[string]Dictionary, len(dm.rankedDictionaries)+1)
 	for k, v := range dm.rankedDictionaries {
 		rd2[k] = v
 	}
 	rd2[name] = d
-	return dictionaryMatch{rankedDictionaries: rd2}
+	return dm.with(rd2)
+}
+
+// Dictionary is a list of lowercase words ranked from the most common, 1 being the rank
+// of the most common word. It is implemented by RankedDictionary and by the lists of
+// a packed.Dict returned by PackedDictionaries.
+type Dictionary interface {
+	// Rank returns the rank of word, or false if the dictionary does not hold it.
+	Rank(word string) (int, bool)
+	// Each calls fn for every word of the dictionary until fn returns false.
+	Each(fn func(word string, rank int) bool)
+}
+
+// RankedDictionary maps a lowercase word to its rank, 1 being the most common.
+type RankedDictionary map[string]int
+
+// Rank returns the rank of word, or false if d does not hold it.
+func (d RankedDictionary) Rank(word string) (int, bool) {
+	rank, ok := d[word]
+	return rank, ok
 }
 
-type rankedDictionnary map[string]int
+// Each calls fn for every word of d, in no particular order, until fn returns false.
+func (d RankedDictionary) Each(fn func(word string, rank int) bool) {
+	for word, rank := range d {
+		if !fn(word, rank) {
+			return
+		}
+	}
+}
 
-func buildRankedDict(unrankedList []string) rankedDictionnary {
-	result := make(rankedDictionnary)
+// BuildRankedDict returns a RankedDictionary ranking the words of unrankedList by their order.
+func BuildRankedDict(unrankedList []string) RankedDictionary {
+	result := make(RankedDictionary)
 
 	for i, v := range unrankedList {
 		result[strings.ToLower(v)] = i + 1
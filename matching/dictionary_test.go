@@ -1,34 +1,39 @@
 package matching
 
 import (
-	"github.com/stretchr/testify/assert"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akara-io/zxcvbn/frequency/packed"
 	"github.com/akara-io/zxcvbn/match"
 )
 
 func Test_dictionaryMatch(t *testing.T) {
-	dm := dictionaryMatch{
-		rankedDictionaries: map[string]rankedDictionnary{
-			"d1": rankedDictionnary{
-				"motherboard": 1,
-				"mother":      2,
-				"board":       3,
-				"abcd":        4,
-				"cdef":        5,
-				"touché":      6,
-				"先生":          7,
-				"猫咪":          8,
-			},
-			"d2": rankedDictionnary{
-				"z":          1,
-				"8":          2,
-				"99":         3,
-				"$":          4,
-				"asdf1234&*": 5,
-			},
+	dm := newDictionaryMatch(map[string]Dictionary{
+		"d1": RankedDictionary{
+			"motherboard": 1,
+			"mother":      2,
+			"board":       3,
+			"abcd":        4,
+			"cdef":        5,
+			"touché":      6,
+			"先生":          7,
+			"猫咪":          8,
 		},
-	}
+		"d2": RankedDictionary{
+			"z":          1,
+			"8":          2,
+			"99":         3,
+			"$":          4,
+			"asdf1234&*": 5,
+		},
+	})
 	tests := []struct {
 		name     string
 		password string
@@ -173,10 +178,10 @@ func Test_dictionaryMatch(t *testing.T) {
 
 	// matches against all words in provided dictionaries
 	for name, dict := range dm.rankedDictionaries {
-		for word, rank := range dict {
+		dict.Each(func(word string, rank int) bool {
 			if word == "motherboard" {
 				// skip words that contain others
-				continue
+				return true
 			}
 			assert.Equal(t, []*match.Match{
 				{
@@ -188,12 +193,13 @@ func Test_dictionaryMatch(t *testing.T) {
 					I:              0,
 					J:              len(word) - 1,
 				}}, dm.Matches(word))
-		}
+			return true
+		})
 	}
 }
 
 func Test_defaultdictionary(t *testing.T) {
-	got := defaultRankedDictionnaries.Matches("wow")
+	got := loadDefaultDictionnaries().Matches("wow")
 	assert.Equal(t, []*match.Match{
 		{
 			Pattern:        "dictionary",
@@ -205,9 +211,9 @@ func Test_defaultdictionary(t *testing.T) {
 			J:              2,
 		}}, got)
 
-	d := defaultRankedDictionnaries.withDict(
+	d := loadDefaultDictionnaries().withDict(
 		"user_inputs",
-		buildRankedDict([]string{"foo", "bar"}),
+		BuildRankedDict([]string{"foo", "bar"}),
 	)
 	var filtered []*match.Match
 	for _, m := range d.Matches("foobar") {
@@ -236,3 +242,156 @@ func Test_defaultdictionary(t *testing.T) {
 		},
 	}, filtered)
 }
+
+// substringDictionaryMatches is the reference dictionary matcher, looking up
+// every substring of password in every dictionary.
+func substringDictionaryMatches(rankedDictionaries map[string]Dictionary, password string) []*match.Match {
+	var results []*match.Match
+	for dictionaryName, rankedDict := range rankedDictionaries {
+		for i := range password {
+			j := len(password) - 1
+			for delta := range password[i:] {
+				if delta > 0 {
+					j = i + delta - 1
+				}
+				word := strings.ToLower(password[i : j+1])
+				if val, ok := rankedDict.Rank(word); ok {
+					results = append(results, &match.Match{
+						Pattern:        "dictionary",
+						I:              i,
+						J:              j,
+						Token:          password[i : j+1],
+						MatchedWord:    word,
+						Rank:           val,
+						DictionaryName: dictionaryName,
+					})
+				}
+			}
+		}
+	}
+	sort.SliceStable(results, func(a, b int) bool {
+		if results[a].I != results[b].I {
+			return results[a].I < results[b].I
+		}
+		if results[a].J != results[b].J {
+			return results[a].J < results[b].J
+		}
+		return results[a].DictionaryName < results[b].DictionaryName
+	})
+	return results
+}
+
+func Test_dictionaryMatchIsSubstringLookup(t *testing.T) {
+	dm := loadDefaultDictionnaries().withDict("user_inputs", BuildRankedDict([]string{"Café", "ÉCOLE", "先生"}))
+	for _, password := range []string{
+		"",
+		"correcthorsebatterystaple",
+		"Tr0ub4dour&3",
+		"MotherBoardPassw0rd1990",
+		"lecafédel'école先生",
+		"jeNeSaisPasQuoiDireIciPourLeTest",
+	} {
+		assert.Equal(t, substringDictionaryMatches(dm.rankedDictionaries, password), dm.Matches(password), password)
+	}
+}
+
+func Test_dictionaryMatchWith(t *testing.T) {
+	d1 := RankedDictionary{"mother": 1}
+	d2 := RankedDictionary{"board": 1}
+	dm := newDictionaryMatch(map[string]Dictionary{"d1": d1, "d2": d2})
+
+	// unchanged dictionaries share their index
+	dm2 := dm.with(map[string]Dictionary{"d1": d1})
+	assert.Len(t, dm2.indexes, 1)
+	assert.Same(t, dm.indexes[0].index, dm2.indexes[0].index)
+	assert.Equal(t, []string{"mother"}, matchedWords(dm2.Matches("motherboard")))
+
+	// replaced dictionaries are indexed again
+	dm3 := dm.withDict("d2", RankedDictionary{"other": 1})
+	assert.Len(t, dm3.indexes, 2)
+	assert.Equal(t, []string{"mother", "other"}, matchedWords(dm3.Matches("motherboard")))
+	assert.Equal(t, []string{"mother", "board"}, matchedWords(dm.Matches("motherboard")))
+}
+
+func Test_packedDictionaries(t *testing.T) {
+	lists := map[string][]string{
+		"d1": {"Mother", "board", "motherboard", "héros"},
+		"d2": {"the", "other", "boa"},
+	}
+	d, err := packed.New(packed.Encode(lists))
+	require.NoError(t, err)
+	pd := PackedDictionaries(d)
+	rd := map[string]Dictionary{}
+	for name, words := range lists {
+		rd[name] = BuildRankedDict(words)
+	}
+
+	rank, ok := pd["d1"].Rank("motherboard")
+	assert.True(t, ok)
+	assert.Equal(t, 3, rank)
+	_, ok = pd["d2"].Rank("motherboard")
+	assert.False(t, ok)
+
+	// packed dictionaries are matched in place, without building a trie
+	dm := newDictionaryMatch(pd)
+	require.Len(t, dm.indexes, 1)
+	assert.IsType(t, packedIndex{}, dm.indexes[0].index)
+	for _, password := range []string{"", "MotherBoard", "theHérosBoard", "xmotherboardx"} {
+		assert.Equal(t, newDictionaryMatch(rd).Matches(password), dm.Matches(password), password)
+	}
+
+	// lists renamed or mixed with other dictionaries keep matching
+	mixed := newDictionaryMatch(map[string]Dictionary{"renamed": pd["d1"], "d2": pd["d2"], "d3": RankedDictionary{"her": 1}})
+	assert.Len(t, mixed.indexes, 2)
+	assert.Equal(t, substringDictionaryMatches(mixed.rankedDictionaries, "motherboard"), mixed.Matches("motherboard"))
+}
+
+func matchedWords(matches []*match.Match) []string {
+	var words []string
+	for _, m := range matches {
+		words = append(words, m.MatchedWord)
+	}
+	return words
+}
+
+var benchmarkPasswords = []string{
+	"correcthorsebatterystaple",
+	"Tr0ub4dour&3",
+	strings.Repeat("thequickbrownfoxjumpsoverthelazydog", 4),
+}
+
+// largeDictionary returns n distinct pseudo-words.
+func largeDictionary(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = strconv.FormatInt(int64(i)*7919+1000003, 36)
+	}
+	return words
+}
+
+func BenchmarkDictionaryMatch(b *testing.B) {
+	defaults := loadDefaultDictionnaries()
+	custom := defaults.withDict("custom", BuildRankedDict(largeDictionary(500000)))
+	for _, password := range benchmarkPasswords {
+		b.Run(fmt.Sprintf("index/default/%d", len(password)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				defaults.Matches(password)
+			}
+		})
+		b.Run(fmt.Sprintf("substrings/default/%d", len(password)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				substringDictionaryMatches(defaults.rankedDictionaries, password)
+			}
+		})
+		b.Run(fmt.Sprintf("index/custom/%d", len(password)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				custom.Matches(password)
+			}
+		})
+		b.Run(fmt.Sprintf("substrings/custom/%d", len(password)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				substringDictionaryMatches(custom.rankedDictionaries, password)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package matching
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/akara-io/zxcvbn/adjacency"
+	"github.com/akara-io/zxcvbn/match"
+)
+
+// LayoutSwitch is a pair of keyboard layouts: words of the layout Intended are looked for
+// in passwords typed with the layout Typed active, like пароль in gfhjkm typed with qwerty
+// instead of jcuken. The characters are mapped from key to key, as described by
+// adjacency.LayoutSwitch.
+type LayoutSwitch struct {
+	Typed, Intended *adjacency.Graph
+}
+
+// layoutSwitch is a LayoutSwitch with its mapping of characters.
+type layoutSwitch struct {
+	LayoutSwitch
+	chars map[rune]rune
+}
+
+func newLayoutSwitches(switches []LayoutSwitch) []layoutSwitch {
+	res := make([]layoutSwitch, 0, len(switches))
+	for _, s := range switches {
+		if s.Typed == nil || s.Intended == nil {
+			continue
+		}
+		if chars := adjacency.LayoutSwitch(s.Typed, s.Intended); len(chars) > 0 {
+			res = append(res, layoutSwitch{LayoutSwitch: s, chars: chars})
+		}
+	}
+	return res
+}
+
+// layoutSwitchMatch finds dictionary words typed with the wrong keyboard layout active,
+// by mapping the password through each layout switch.
+type layoutSwitchMatch struct {
+	dm       dictionaryMatch
+	switches []layoutSwitch
+}
+
+func (lm layoutSwitchMatch) Matches(password string) []*match.Match {
+	matches, _ := lm.matchesContext(context.Background(), password)
+	return matches
+}
+
+func (lm layoutSwitchMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
+	var matches []*match.Match
+	for _, s := range lm.switches {
+		var b strings.Builder
+		// starts and ends are the byte offsets in the password of the character each byte
+		// of the switched password comes from
+		var starts, ends []int
+		switched := false
+		for i, r := range password {
+			_, size := utf8.DecodeRuneInString(password[i:])
+			if c, ok := s.chars[r]; ok {
+				r = c
+				switched = true
+			}
+			n, _ := b.WriteRune(r)
+			for k := 0; k < n; k++ {
+				starts = append(starts, i)
+				ends = append(ends, i+size)
+			}
+		}
+		if !switched {
+			continue
+		}
+		found, err := lm.dm.matchesContext(ctx, b.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range found {
+			i, j := starts[m.I], ends[m.J]-1
+			if password[i:j+1] == m.Token {
+				// no character of the word was switched
+				continue
+			}
+			m.I, m.J = i, j
+			m.Token = password[i : j+1]
+			m.Layout = s.Intended.Name
+			matches = append(matches, m)
+		}
+	}
+	match.Sort(matches)
+	return matches, nil
+}
@@ -0,0 +1,102 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akara-io/zxcvbn/adjacency"
+	"github.com/akara-io/zxcvbn/match"
+)
+
+func Test_layoutSwitchMatch(t *testing.T) {
+	lm := layoutSwitchMatch{
+		dm: newDictionaryMatch(map[string]Dictionary{
+			"words": RankedDictionary{"пароль": 1, "шалом": 2, "שלום": 3, "1": 4},
+		}),
+		switches: newLayoutSwitches([]LayoutSwitch{
+			{Typed: adjacency.Get("qwerty"), Intended: adjacency.Get("jcuken")},
+			{Typed: adjacency.Get("qwerty"), Intended: adjacency.Get("hebrew")},
+		}),
+	}
+
+	assert.Equal(t, []*match.Match{
+		{
+			Pattern:        "dictionary",
+			I:              2,
+			J:              7,
+			Token:          "GFHJKM",
+			MatchedWord:    "пароль",
+			Rank:           1,
+			DictionaryName: "words",
+			Layout:         "jcuken",
+		},
+	}, lm.Matches("1!GFHJKM"))
+
+	matches := lm.Matches("akuo")
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, "שלום", matches[0].MatchedWord)
+		assert.Equal(t, "akuo", matches[0].Token)
+		assert.Equal(t, "hebrew", matches[0].Layout)
+	}
+
+	// the words typed with their own layout are left to the dictionary matcher
+	assert.Empty(t, lm.Matches("пароль"))
+	assert.Empty(t, lm.Matches("123"))
+
+	// the offsets are those of the password
+	matches = lm.Matches("шfkjv")
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, "шfkjv", matches[0].Token)
+		assert.Equal(t, 0, matches[0].I)
+		assert.Equal(t, len("шfkjv")-1, matches[0].J)
+	}
+}
+
+// countLayoutSwitches returns the number of switches from the layout typed to intended.
+func countLayoutSwitches(switches []LayoutSwitch, typed, intended string) int {
+	n := 0
+	for _, s := range switches {
+		if s.Typed.Name == typed && s.Intended.Name == intended {
+			n++
+		}
+	}
+	return n
+}
+
+func TestOmnimatcherLayoutSwitches(t *testing.T) {
+	// there are no switches by default
+	assert.Empty(t, DefaultConfig().LayoutSwitches)
+	for _, m := range Default().Matches("gfhjkm", []string{"пароль"}) {
+		assert.Empty(t, m.Layout)
+	}
+
+	om := NewOmnimatcher(Config{LayoutSwitches: []LayoutSwitch{
+		{Typed: adjacency.Get("qwerty"), Intended: adjacency.Get("jcuken")},
+	}})
+	matches := om.Matches("gfhjkm", []string{"пароль"})
+	assert.Contains(t, matches, &match.Match{
+		Pattern:        "dictionary",
+		I:              0,
+		J:              5,
+		Token:          "gfhjkm",
+		MatchedWord:    "пароль",
+		Rank:           1,
+		DictionaryName: "user_inputs",
+		Layout:         "jcuken",
+	})
+
+	om = NewOmnimatcher(Config{LayoutSwitches: []LayoutSwitch{
+		{Typed: adjacency.Get("jcuken"), Intended: adjacency.Get("qwerty")},
+	}})
+	assert.Contains(t, om.Matches("зфыыцщкв", nil), &match.Match{
+		Pattern:        "dictionary",
+		I:              0,
+		J:              len("зфыыцщкв") - 1,
+		Token:          "зфыыцщкв",
+		MatchedWord:    "password",
+		Rank:           2,
+		DictionaryName: "passwords",
+		Layout:         "qwerty",
+	})
+}
@@ -1,9 +1,11 @@
 package matching
 
 import (
-	"bytes"
+	"context"
 	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/akara-io/zxcvbn/match"
 )
@@ -14,155 +16,237 @@ type l33tMatch struct {
 }
 
 func (lm l33tMatch) Matches(password string) []*match.Match {
-	matches := []*match.Match{}
-
-	substitutions := relevantSubtable(password, lm.table)
-
-	for _, sub := range enumerateLeetSubs(substitutions) {
-		if len(sub) == 0 {
-			break
-		}
-		subbedPassword := translate(password, sub)
-		for _, m := range lm.dm.Matches(subbedPassword) {
-			token := password[m.I : m.J+1]
-			if len(token) <= 1 {
-				// filter single-character l33t matches to reduce noise.
-				// otherwise '1' matches 'i', '4' matches 'a', both very common English words
-				continue
-			}
+	matches, _ := lm.matchesContext(context.Background(), password)
+	return matches
+}
 
-			if strings.ToLower(token) == m.MatchedWord {
-				continue // only return the matches that return an actual substitution
-			}
-			m.Sub = make(map[string]string)
-			for subbed, chr := range sub {
-				if strings.Contains(token, subbed) {
-					m.Sub[subbed] = chr
-				}
+func (lm l33tMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
+	table := relevantSubtable(password, lm.table)
+	w := l33tWalk{
+		password: password,
+		alts:     l33tAlternatives(password, table),
+		singles:  make(map[rune]string),
+		matches:  []*match.Match{},
+	}
+	for _, values := range table {
+		for _, value := range values {
+			if r, size := utf8.DecodeRuneInString(value); size == len(value) {
+				w.singles[unicode.ToLower(r)] = value
 			}
-			m.L33t = true
-			m.Token = token
-			matches = append(matches, m)
 		}
+	}
 
+	for i := range password {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		w.start = i
+		for _, idx := range lm.dm.indexes {
+			w.idx = idx
+			w.walk(idx.index.root(), i, false)
+		}
 	}
+	match.Sort(w.matches)
+	return w.matches, nil
+}
 
-	match.Sort(matches)
-	return matches
+// l33tSub is the substitution of a letter by a l33t string of the table.
+type l33tSub struct {
+	l33t, letter string
+}
+
+// l33tAlt is a substitution that may be undone at a position of the password, the l33t
+// string ending at end.
+type l33tAlt struct {
+	l33tSub
+	end int
 }
 
-func translate(password string, sub map[string]string) string {
-	var res string
-	for _, s := range password {
-		if v, ok := sub[string(s)]; ok {
-			res = res + v
-		} else {
-			res = res + string(s)
+// l33tAlternatives returns, for each byte offset of password, the substitutions of table
+// whose l33t string starts there, regardless of case.
+func l33tAlternatives(password string, table map[string][]string) [][]l33tAlt {
+	letters := make([]string, 0, len(table))
+	for letter := range table {
+		letters = append(letters, letter)
+	}
+	sort.Strings(letters)
+
+	alts := make([][]l33tAlt, len(password))
+	for i := range password {
+		for _, letter := range letters {
+			for _, l33t := range table[letter] {
+				if hasPrefixFold(password[i:], l33t) {
+					alts[i] = append(alts[i], l33tAlt{l33tSub{l33t, letter}, i + len(l33t)})
+				}
+			}
 		}
 	}
-	return res
+	return alts
 }
 
-type kv struct {
-	k string
-	v string
+// l33tWalk finds the l33t words of a password starting at a position by walking the
+// words of an index: each character is read either as itself or as the letter of one of
+// the substitutions starting there. Walking stops as soon as no word of the index
+// starts with what was read, which keeps the cost bounded by the size of the index
+// rather than growing with the number of ways to read the password.
+//
+// Substitutions are consistent within a word: a l33t string stands for a single letter
+// and a letter is substituted by a single l33t string, and a character read as itself
+// isn't substituted elsewhere in the word.
+type l33tWalk struct {
+	password string
+	alts     [][]l33tAlt
+	// singles are the l33t strings of a single character, by lowercase character.
+	singles map[rune]string
+	start   int
+	idx     dictionaryIndex
+
+	word     []byte
+	subs     []l33tSub // the substitutions undone in word
+	literals []string  // the single-character l33t strings read as themselves in word
+	matches  []*match.Match
 }
 
-func dedup(subs [][]kv) [][]kv {
-	var res [][]kv
-	var b bytes.Buffer
-	members := make(map[string]bool)
-	for _, sub := range subs {
-		sort.SliceStable(sub, func(i, j int) bool {
-			return sub[i].k < sub[j].k
-		})
-		b.Reset()
-		for _, x := range sub {
-			b.WriteString(x.k)
-			b.WriteString(",")
-			b.WriteString(x.v)
+// walk continues reading the password at pos, p being the position in the index of the
+// word read so far and subbed telling whether a substitution was undone to read it.
+func (w *l33tWalk) walk(p wordPos, pos int, subbed bool) {
+	if subbed {
+		w.emit(p, pos)
+	}
+	if pos == len(w.password) {
+		return
+	}
+
+	r, size := utf8.DecodeRuneInString(w.password[pos:])
+	lr := unicode.ToLower(r)
+	if next, ok := w.idx.index.next(p, lr); ok {
+		single := w.singles[lr]
+		if single == "" || w.readLiterally(single) {
+			n, literals := len(w.word), len(w.literals)
+			if single != "" {
+				w.literals = append(w.literals, single)
+			}
+			w.word = utf8.AppendRune(w.word, lr)
+			w.walk(next, pos+size, subbed)
+			w.word, w.literals = w.word[:n], w.literals[:literals]
 		}
-		key := b.String()
-		if !members[key] {
-			res = append(res, sub)
-			members[key] = true
+	}
+
+	for _, alt := range w.alts[pos] {
+		added, ok := w.substitute(alt.l33tSub)
+		if !ok {
+			continue
+		}
+		next := p
+		for _, c := range alt.letter {
+			if next, ok = w.idx.index.next(next, c); !ok {
+				break
+			}
+		}
+		if ok {
+			n := len(w.word)
+			w.word = append(w.word, alt.letter...)
+			w.walk(next, alt.end, true)
+			w.word = w.word[:n]
+		}
+		if added {
+			w.subs = w.subs[:len(w.subs)-1]
 		}
 	}
-	return res
 }
 
-// enumerateLeetSubs returns the list of possible 1337 replacement dictionaries for a given password
-func enumerateLeetSubs(table map[string][]string) []map[string]string {
-	var keys []string
-	for k := range table {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-	var subs = [][]kv{[]kv{}}
-
-	var helper func(keys []string)
-	helper = func(keys []string) {
-		if len(keys) == 0 {
-			return
-		}
-		firstKey := keys[0]
-		restKeys := keys[1:]
-		var nextSubs [][]kv
-		for _, l33tChr := range table[firstKey] {
-			for _, sub := range subs {
-				dupL33tIndex := -1
-				for i := 0; i < len(sub); i++ {
-					if sub[i].k == l33tChr {
-						dupL33tIndex = i
-						break
-					}
-				}
-				if dupL33tIndex == -1 {
-					subExtension := append(sub, kv{k: l33tChr, v: firstKey})
-					nextSubs = append(nextSubs, subExtension)
-				} else {
-					subAlternative := make([]kv, 0, len(sub))
-					subAlternative = append(subAlternative, sub[0:dupL33tIndex]...)
-					subAlternative = append(subAlternative, sub[dupL33tIndex+1:]...)
-					subAlternative = append(subAlternative, kv{k: l33tChr, v: firstKey})
-					// subAlternative := make([]kv, 0, len(sub))
-					// subAlternative = append(subAlternative, sub)
-					// subAlternative[dupL33tIndex] = {k:l33tChr,v:firstKey}
-					nextSubs = append(nextSubs, sub)
-					nextSubs = append(nextSubs, subAlternative)
-				}
-			}
+// readLiterally reports whether the single-character l33t string may be read as itself,
+// not being substituted in the word.
+func (w *l33tWalk) readLiterally(l33t string) bool {
+	for _, s := range w.subs {
+		if s.l33t == l33t {
+			return false
 		}
-		subs = dedup(nextSubs)
-		helper(restKeys)
 	}
+	return true
+}
 
-	helper(keys)
-	var subDicts []map[string]string
-	for _, sub := range subs {
-		subDict := make(map[string]string)
+// substitute adds sub to the substitutions of the word, reporting whether it was added
+// and whether it is consistent with them.
+func (w *l33tWalk) substitute(sub l33tSub) (added, ok bool) {
+	for _, s := range w.subs {
+		if s == sub {
+			return false, true
+		}
+		if s.l33t == sub.l33t || s.letter == sub.letter {
+			return false, false
+		}
+	}
+	for _, l := range w.literals {
+		if l == sub.l33t {
+			return false, false
+		}
+	}
+	w.subs = append(w.subs, sub)
+	return true, true
+}
 
-		for _, x := range sub {
-			subDict[x.k] = x.v
+// emit adds the matches of the word read up to end, at p in the index.
+func (w *l33tWalk) emit(p wordPos, end int) {
+	entries := w.idx.index.wordEntries(p)
+	if len(entries) == 0 {
+		return
+	}
+	word := string(w.word)
+	if utf8.RuneCountInString(word) <= 1 {
+		// filter single-character l33t matches to reduce noise.
+		// otherwise '1' matches 'i', '4' matches 'a', both very common English words
+		return
+	}
+	token := w.password[w.start:end]
+	if strings.ToLower(token) == word {
+		return // only return the matches that return an actual substitution
+	}
+	for _, e := range entries {
+		if !w.idx.enabled[e.dict] {
+			continue
+		}
+		sub := make(map[string]string, len(w.subs))
+		for _, s := range w.subs {
+			sub[s.l33t] = s.letter
 		}
-		subDicts = append(subDicts, subDict)
+		w.matches = append(w.matches, &match.Match{
+			Pattern:        "dictionary",
+			I:              w.start,
+			J:              end - 1,
+			Token:          token,
+			MatchedWord:    word,
+			Rank:           int(e.rank),
+			DictionaryName: w.idx.index.names()[e.dict],
+			L33t:           true,
+			Sub:            sub,
+		})
 	}
-	return subDicts
 }
 
 func relevantSubtable(password string, table map[string][]string) map[string][]string {
+	lower := strings.ToLower(password)
 	passwordChars := make(map[rune]bool)
-	for _, chr := range password {
+	for _, chr := range lower {
 		passwordChars[chr] = true
 	}
 
 	relevantSubs := make(map[string][]string)
 	for key, values := range table {
 		for _, value := range values {
-			if passwordChars[rune(value[0])] {
+			if relevantSub(lower, passwordChars, value) {
 				relevantSubs[key] = append(relevantSubs[key], value)
 			}
 		}
 	}
 	return relevantSubs
 }
+
+// relevantSub reports whether the lowercase password, made of passwordChars, contains
+// the substitution value, regardless of case.
+func relevantSub(lower string, passwordChars map[rune]bool, value string) bool {
+	if r, size := utf8.DecodeRuneInString(value); size == len(value) {
+		return passwordChars[unicode.ToLower(r)]
+	}
+	return strings.Contains(lower, strings.ToLower(value))
+}
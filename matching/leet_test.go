@@ -1,9 +1,10 @@
 package matching
 
 import (
+	"fmt"
 	"github.com/google/go-cmp/cmp"
 	"reflect"
-	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/akara-io/zxcvbn/match"
@@ -57,54 +58,19 @@ func Test_relevantSubtable(t *testing.T) {
 	}
 }
 
-func Test_enumerateLeetSubs(t *testing.T) {
-	// enumerates the different sets of l33t substitutions a password might be using
-	type args struct {
-		table map[string][]string
-	}
-	tests := []struct {
-		table map[string][]string
-		want  []map[string]string
-	}{
-		{
-			table: map[string][]string{},
-			want:  []map[string]string{{}},
-		},
-		{
-			table: map[string][]string{"a": {"@"}},
-			want:  []map[string]string{{"@": "a"}},
-		},
-		{
-			table: map[string][]string{"a": {"@", "4"}},
-			want:  []map[string]string{{"@": "a"}, {"4": "a"}},
-		},
-		{
-			table: map[string][]string{"a": {"@", "4"}, "c": {"("}},
-			want:  []map[string]string{{"@": "a", "(": "c"}, {"4": "a", "(": "c"}},
-		},
-	}
-	for i, tt := range tests {
-		t.Run("test_"+strconv.Itoa(i), func(t *testing.T) {
-			assert.Equal(t, tt.want, enumerateLeetSubs(tt.table))
-		})
-	}
-}
-
 func Test_l33tMatch(t *testing.T) {
 	lm := l33tMatch{
-		dm: dictionaryMatch{
-			rankedDictionaries: map[string]rankedDictionnary{
-				"words": rankedDictionnary{
-					"aac":       1,
-					"password":  3,
-					"paassword": 4,
-					"asdf0":     5,
-				},
-				"words2": rankedDictionnary{
-					"cgo": 1,
-				},
+		dm: newDictionaryMatch(map[string]Dictionary{
+			"words": RankedDictionary{
+				"aac":       1,
+				"password":  3,
+				"paassword": 4,
+				"asdf0":     5,
 			},
-		},
+			"words2": RankedDictionary{
+				"cgo": 1,
+			},
+		}),
 		table: testl33tTable,
 	}
 	tests := []struct {
@@ -228,20 +194,82 @@ func Test_l33tMatch(t *testing.T) {
 	// doesn't match single-character l33ted words
 	assert.Len(t, lm.Matches("4 1 @"), 0)
 
-	// known issue: subsets of substitutions aren't tried.
-	// for long inputs, trying every subset of every possible substitution could quickly get large,
-	// but there might be a performant way to fix.
-	// (so in this example: {'4': a, '0': 'o'} is detected as a possible sub,
-	// but the subset {'4': 'a'} isn't tried, missing the match for asdf0.)
-	// TODO: consider partially fixing by trying all subsets of size 1 and maybe 2
-	assert.Len(t, lm.Matches("4sdf0"), 0)
+	// substituted characters may be kept as they are elsewhere in the password:
+	// the 4 of 4sdf0 is an a, its 0 is kept for asdf0.
+	assert.Equal(t, []*match.Match{
+		{
+			Pattern:        "dictionary",
+			Token:          "4sdf0",
+			MatchedWord:    "asdf0",
+			Rank:           5,
+			DictionaryName: "words",
+			I:              0,
+			J:              4,
+			L33t:           true,
+			Sub:            map[string]string{"4": "a"},
+		},
+	}, lm.Matches("4sdf0"))
+}
+
+func Test_l33tMatchMultiChar(t *testing.T) {
+	lm := l33tMatch{
+		dm: newDictionaryMatch(map[string]Dictionary{
+			"words": RankedDictionary{
+				"hack":   1,
+				"wow":    2,
+				"poker":  3,
+				"moscow": 4,
+			},
+		}),
+		table: map[string][]string{
+			"a": {"4"},
+			"c": {"("},
+			"h": {"|-|"},
+			"k": {"|<"},
+			"o": {"0", "о"},
+			"w": {"vv"},
+			"e": {"3"},
+		},
+	}
+	for _, tt := range []struct {
+		password string
+		token    string
+		i        int
+		word     string
+		sub      map[string]string
+	}{
+		{"|-|4(|<", "|-|4(|<", 0, "hack", map[string]string{"|-|": "h", "4": "a", "(": "c", "|<": "k"}},
+		{"xx|-|ack!", "|-|ack", 2, "hack", map[string]string{"|-|": "h"}},
+		{"VVoVV", "VVoVV", 0, "wow", map[string]string{"vv": "w"}},
+		{"p0|<3r", "p0|<3r", 0, "poker", map[string]string{"0": "o", "|<": "k", "3": "e"}},
+		// a cyrillic о
+		{"1mоscow", "mоscow", 1, "moscow", map[string]string{"о": "o"}},
+	} {
+		t.Run(tt.password, func(t *testing.T) {
+			matches := lm.Matches(tt.password)
+			if assert.Len(t, matches, 1) {
+				m := matches[0]
+				assert.Equal(t, tt.token, m.Token)
+				assert.Equal(t, tt.token, tt.password[m.I:m.J+1])
+				assert.Equal(t, tt.i, m.I)
+				assert.Equal(t, tt.word, m.MatchedWord)
+				assert.Equal(t, tt.sub, m.Sub)
+			}
+		})
+	}
+
+	// substitutions are relevant when the password contains them, regardless of case
+	assert.Equal(t, map[string][]string{"h": {"|-|"}, "w": {"vv"}},
+		relevantSubtable("|-|VV|", lm.table))
+	assert.Equal(t, map[string][]string{"o": {"о"}},
+		relevantSubtable("О|-", lm.table))
 }
 
 func TestDeterministicOutput(t *testing.T) {
 	password := "coRrecth0rseba++ery9.23.2007staple$"
 
 	lm := l33tMatch{
-		dm:    defaultRankedDictionnaries,
+		dm:    loadDefaultDictionnaries(),
 		table: l33tTable,
 	}
 
@@ -256,3 +284,23 @@ func TestDeterministicOutput(t *testing.T) {
 		lastMatches = matches
 	}
 }
+
+func BenchmarkL33tMatch(b *testing.B) {
+	lm := l33tMatch{dm: loadDefaultDictionnaries(), table: ExtendedL33tTable}
+	for _, bm := range []struct {
+		name     string
+		password string
+	}{
+		{"short", "p@$$w0rd"},
+		{"phrase", "coRrecth0rseba++ery9.23.2007staple$"},
+		// every character substitutes one or several letters, alone or with its neighbours
+		{"symbols", strings.Repeat("1|7!@4$5+0(3", 8)},
+		{"bars", strings.Repeat("|", 64)},
+	} {
+		b.Run(fmt.Sprintf("%s/%d", bm.name, len(bm.password)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				lm.Matches(bm.password)
+			}
+		})
+	}
+}
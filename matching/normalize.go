@@ -0,0 +1,163 @@
+package matching
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization is the Unicode normalization form passwords are put in before being
+// matched against dictionaries, so that a letter is found however it is encoded.
+type Normalization int
+
+const (
+	// NFC composes letters and their combining diacritics, like e followed by U+0301 into é.
+	NFC Normalization = iota
+	// NFKC also replaces compatibility characters by their usual form, like the fullwidth
+	// ｐ by p or the ligature ﬁ by fi.
+	NFKC
+)
+
+func (n Normalization) form() norm.Form {
+	if n == NFKC {
+		return norm.NFKC
+	}
+	return norm.NFC
+}
+
+// normalizedText is a password put in a normalization form.
+type normalizedText struct {
+	text string
+	// starts and ends are the byte offsets in the password of the characters each byte of
+	// text comes from. They are nil if the password is already normalized.
+	starts, ends []int
+}
+
+// normalize returns password put in form.
+func normalize(password string, form norm.Form) normalizedText {
+	if form.IsNormalString(password) {
+		return normalizedText{text: password}
+	}
+	var t normalizedText
+	var b strings.Builder
+	for i := 0; i < len(password); {
+		// normalizing the segments between boundaries one by one normalizes the whole text
+		n := form.NextBoundaryInString(password[i:], true)
+		if n <= 0 {
+			n = len(password) - i
+		}
+		segment := form.String(password[i : i+n])
+		b.WriteString(segment)
+		for k := 0; k < len(segment); k++ {
+			t.starts = append(t.starts, i)
+			t.ends = append(t.ends, i+n)
+		}
+		i += n
+	}
+	t.text = b.String()
+	return t
+}
+
+// boundary reports whether the byte offset i of the text starts a character of the
+// password, or ends the text. Words only start and end at boundaries, so that a word
+// isn't found in a part of a character, like fi in the ligature ﬁ.
+func (t normalizedText) boundary(i int) bool {
+	return t.starts == nil || i == 0 || i == len(t.text) || t.starts[i] != t.starts[i-1]
+}
+
+// span returns the start and end in the password of the bytes of the text from i to j,
+// included.
+func (t normalizedText) span(i, j int) (start, end int) {
+	if t.starts == nil {
+		return i, j + 1
+	}
+	return t.starts[i], t.ends[j]
+}
+
+var (
+	diacriticsOnce sync.Once
+	// diacriticBases maps a lowercase letter with diacritics to its base letter, like é to e.
+	diacriticBases map[rune]rune
+	// diacriticVariants maps a lowercase base letter to the letters with diacritics
+	// having it as their base, like e to è, é, ê, ë...
+	diacriticVariants map[rune][]rune
+)
+
+// diacriticRanges are the blocks of the letters with diacritics that are folded:
+// Latin-1 Supplement, Latin Extended-A and B, Greek, Cyrillic and Latin Extended Additional.
+var diacriticRanges = [][2]rune{
+	{0x00c0, 0x024f},
+	{0x0370, 0x03ff},
+	{0x0400, 0x04ff},
+	{0x1e00, 0x1eff},
+}
+
+// diacriticLetters are the letters with diacritics that have no decomposition.
+var diacriticLetters = map[rune]rune{
+	'ø': 'o',
+	'ł': 'l',
+	'đ': 'd',
+	'ħ': 'h',
+	'ŧ': 't',
+}
+
+func loadDiacritics() {
+	diacriticsOnce.Do(func() {
+		diacriticBases = make(map[rune]rune)
+		for r, base := range diacriticLetters {
+			diacriticBases[r] = base
+		}
+		for _, rng := range diacriticRanges {
+			for r := rng[0]; r <= rng[1]; r++ {
+				if !unicode.IsLower(r) {
+					continue
+				}
+				decomposed := norm.NFD.String(string(r))
+				base, size := utf8.DecodeRuneInString(decomposed)
+				if size == len(decomposed) || !unicode.IsLetter(base) {
+					continue
+				}
+				if strings.IndexFunc(decomposed[size:], func(c rune) bool { return !unicode.Is(unicode.Mn, c) }) < 0 {
+					diacriticBases[r] = base
+				}
+			}
+		}
+		diacriticVariants = make(map[rune][]rune)
+		for r, base := range diacriticBases {
+			diacriticVariants[base] = append(diacriticVariants[base], r)
+		}
+		for _, variants := range diacriticVariants {
+			sort.Slice(variants, func(i, j int) bool { return variants[i] < variants[j] })
+		}
+	})
+}
+
+// foldDiacritic returns the base letter of the lowercase r, like e for é, or r itself.
+func foldDiacritic(r rune) rune {
+	loadDiacritics()
+	if base, ok := diacriticBases[r]; ok {
+		return base
+	}
+	return r
+}
+
+// diacriticAlternatives returns the lowercase letters that r may stand for in a
+// password typed with or without diacritics: the letters with the same base letter,
+// r first.
+func diacriticAlternatives(r rune) []rune {
+	base := foldDiacritic(r)
+	alts := []rune{r}
+	if base != r {
+		alts = append(alts, base)
+	}
+	for _, v := range diacriticVariants[base] {
+		if v != r {
+			alts = append(alts, v)
+		}
+	}
+	return alts
+}
@@ -0,0 +1,97 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_normalize(t *testing.T) {
+	// already normalized
+	n := normalize("café", NFC.form())
+	assert.Equal(t, "café", n.text)
+	assert.Nil(t, n.starts)
+
+	// e followed by a combining acute accent
+	n = normalize("xcafé!", NFC.form())
+	assert.Equal(t, "xcafé!", n.text)
+	start, end := n.span(1, len("xcafé")-1)
+	assert.Equal(t, 1, start)
+	assert.Equal(t, len("xcafé"), end)
+
+	// the ligature ﬁ is a single character of the password
+	n = normalize("ﬁsh", NFKC.form())
+	assert.Equal(t, "fish", n.text)
+	assert.True(t, n.boundary(0))
+	assert.False(t, n.boundary(1))
+	assert.True(t, n.boundary(2))
+	start, end = n.span(0, 1)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, len("ﬁ"), end)
+}
+
+func Test_diacriticAlternatives(t *testing.T) {
+	assert.Equal(t, 'e', foldDiacritic('é'))
+	assert.Equal(t, 'o', foldDiacritic('ø'))
+	assert.Equal(t, 'x', foldDiacritic('x'))
+
+	alts := diacriticAlternatives('é')
+	assert.Equal(t, []rune{'é', 'e'}, alts[:2])
+	assert.Contains(t, alts, 'ë')
+
+	alts = diacriticAlternatives('n')
+	assert.Equal(t, 'n', alts[0])
+	assert.Contains(t, alts, 'ñ')
+
+	assert.Equal(t, []rune{'7'}, diacriticAlternatives('7'))
+}
+
+func Test_dictionaryMatchNormalization(t *testing.T) {
+	dm := newDictionaryMatch(map[string]Dictionary{
+		"words": RankedDictionary{"café": 1, "password": 2, "ñandú": 3, "fish": 4},
+	})
+
+	// combining diacritics are matched like the precomposed letters
+	matches := dm.Matches("Café")
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, "Café", matches[0].Token)
+		assert.Equal(t, 0, matches[0].I)
+		assert.Equal(t, len("Café")-1, matches[0].J)
+		assert.Equal(t, "café", matches[0].MatchedWord)
+	}
+
+	// compatibility characters are only replaced with NFKC
+	assert.Len(t, dm.Matches("ｆｉｓｈ"), 0)
+	dm.normalization = NFKC
+	matches = dm.Matches("ｆｉｓｈ")
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, "ｆｉｓｈ", matches[0].Token)
+		assert.Equal(t, "fish", matches[0].MatchedWord)
+	}
+	// a word doesn't end inside the ligature
+	assert.Len(t, dm.Matches("ﬁ"), 0)
+
+	// diacritics are only folded when asked
+	assert.Len(t, dm.Matches("pässwörd"), 0)
+	dm.foldDiacritics = true
+	for _, tt := range []struct {
+		password   string
+		word       string
+		diacritics int
+	}{
+		{"café", "café", 0},
+		{"cafe", "café", 1},
+		{"cafè", "café", 1},
+		{"PÄSSWÖRD", "password", 2},
+		{"nandu", "ñandú", 2},
+	} {
+		t.Run(tt.password, func(t *testing.T) {
+			matches := dm.Matches(tt.password)
+			if assert.Len(t, matches, 1) {
+				assert.Equal(t, tt.password, matches[0].Token)
+				assert.Equal(t, tt.word, matches[0].MatchedWord)
+				assert.Equal(t, tt.diacritics, matches[0].Diacritics)
+			}
+		})
+	}
+}
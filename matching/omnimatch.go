@@ -1,46 +1,257 @@
 package matching
 
 import (
+	"context"
 	"regexp"
-	
+
 	"github.com/akara-io/zxcvbn/adjacency"
+	"github.com/akara-io/zxcvbn/breach"
 	"github.com/akara-io/zxcvbn/frequency"
 	"github.com/akara-io/zxcvbn/match"
+	"github.com/akara-io/zxcvbn/scoring"
 )
 
-func Omnimatch(password string, userInputs []string) (matches []*match.Match) {
-	dictMatcher := defaultRankedDictionnaries.withDict("user_inputs", buildRankedDict(userInputs))
+// NamedRegexp is a regular expression reported as a "regex" match under Name.
+type NamedRegexp struct {
+	Name   string
+	Regexp *regexp.Regexp
+	// Guesses estimates the number of guesses needed for a token matched by Regexp, like
+	// the size of the space of the tokens it matches. Nil uses the built-in estimate for
+	// Name, as described by scoring.Scorer.RegexGuesses.
+	Guesses func(token string) float64
+	// Warning and Suggestions are the feedback given when a match of Regexp is the
+	// longest of a weak password. Without them, the built-in feedback for Name is given.
+	Warning     string
+	Suggestions []string
+}
+
+// regexGuesses returns the guesses estimates of the regexes that have one, by name.
+func regexGuesses(regexes []NamedRegexp) map[string]func(token string) float64 {
+	var guesses map[string]func(token string) float64
+	for _, r := range regexes {
+		if r.Guesses != nil {
+			if guesses == nil {
+				guesses = make(map[string]func(token string) float64)
+			}
+			guesses[r.Name] = r.Guesses
+		}
+	}
+	return guesses
+}
+
+// Config holds the data an Omnimatcher matches passwords against.
+// Nil fields fall back to the package defaults; use empty non-nil values to
+// disable a kind of data.
+type Config struct {
+	// Dictionaries maps a dictionary name to its ranked words.
+	Dictionaries map[string]Dictionary
+	// Graphs are the keyboard adjacency graphs used for spatial matching.
+	Graphs []*adjacency.Graph
+	// L33tTable maps a letter to the strings it may be substituted with: characters like
+	// 4 for a, several characters like |< for k, or look-alikes like the cyrillic о for o.
+	L33tTable map[string][]string
+	// Regexes are patterns reported as regex matches, in addition to the recent years.
+	// There are none by default.
+	Regexes []NamedRegexp
+	// DateNames are the languages of the month and weekday names recognized in dates.
+	DateNames []DateNames
+	// ReferenceYear is the year date candidates and recent years are compared to.
+	// Zero means scoring.ReferenceYear at the time of matching.
+	ReferenceYear int
+	// RecentYearWindow is the number of years before ReferenceYear reported as recent_year
+	// regex matches, along with the scoring.MinYearSpace years after it. Zero means
+	// DefaultRecentYearWindow; a negative window disables recent years.
+	RecentYearWindow int
+	// DateMinYear and DateMaxYear bound the four-digit years recognized in dates.
+	// Zero means 1000 and 2050.
+	DateMinYear, DateMaxYear int
+	// TwoDigitYearPivot is the last two-digit year read as 20xx in dates, the later ones
+	// being read as 19xx. Zero means 50.
+	TwoDigitYearPivot int
+	// StrictDates rejects the dates that don't exist, like feb 29 outside leap years,
+	// and the weekdays that don't fall on the date they precede.
+	StrictDates bool
+	// DisablePassphrases stops reporting the words joined by a separator or in camel case,
+	// like correct-horse-battery-staple, as passphrase matches.
+	DisablePassphrases bool
+	// Normalization is the Unicode normalization form passwords are put in before being
+	// matched against dictionaries. The zero value is NFC.
+	Normalization Normalization
+	// FoldDiacritics matches dictionary words regardless of the diacritics of their
+	// letters, like cafe with café or pässwörd with password.
+	FoldDiacritics bool
+	// LayoutSwitches are the pairs of keyboard layouts whose words are looked for in
+	// passwords typed with the wrong layout active. There are none by default: the words
+	// of the intended layout are only found in a dictionary of its language, like a
+	// Russian word list for jcuken, or in the user inputs.
+	LayoutSwitches []LayoutSwitch
+	// BreachFilter holds breached passwords, reported as breached matches.
+	// There is none by default.
+	BreachFilter *breach.Filter
+}
+
+// DefaultConfig returns a Config populated with the package defaults, including the
+// dictionaries added by RegisterDictionary.
+// The returned maps and slices are copies and may be modified freely, but the
+// Dictionary values themselves are shared and must not be mutated.
+func DefaultConfig() Config {
+	defaults := Default()
+	cfg := Config{
+		Dictionaries: make(map[string]Dictionary, len(defaults.dm.rankedDictionaries)),
+		Graphs:       append([]*adjacency.Graph(nil), defaults.graphs...),
+		L33tTable:    copyL33tTable(defaults.l33tTable),
+		Regexes:      append([]NamedRegexp(nil), defaults.regexes...),
+		DateNames:    append([]DateNames(nil), defaults.dates.names.langs...),
+	}
+	for _, s := range defaults.layoutSwitches {
+		cfg.LayoutSwitches = append(cfg.LayoutSwitches, s.LayoutSwitch)
+	}
+	for name, d := range defaults.dm.rankedDictionaries {
+		cfg.Dictionaries[name] = d
+	}
+	return cfg
+}
+
+// Omnimatcher runs every matcher of the package with its own dictionaries,
+// keyboard graphs, l33t table, regexes and breach filter.
+// An Omnimatcher is immutable and safe for concurrent use.
+type Omnimatcher struct {
+	dm        dictionaryMatch
+	graphs    []*adjacency.Graph
+	l33tTable map[string][]string
+	regexes   []NamedRegexp
+	dates     dateMatch
+	years     recentYearMatch
+	breach    *breach.Filter
+	// layoutSwitches are the keyboard layouts whose words are looked for typed with another layout.
+	layoutSwitches []layoutSwitch
+	// passphrases tells whether passphrase matches are reported.
+	passphrases bool
+	scorer      scoring.Scorer
+}
+
+// NewOmnimatcher returns an Omnimatcher using the data in cfg.
+func NewOmnimatcher(cfg Config) *Omnimatcher {
+	defaults := Default()
+	om := &Omnimatcher{
+		dm:             defaults.dm,
+		graphs:         defaults.graphs,
+		l33tTable:      defaults.l33tTable,
+		regexes:        defaults.regexes,
+		layoutSwitches: defaults.layoutSwitches,
+		dates: dateMatch{
+			referenceYear: cfg.ReferenceYear,
+			names:         defaults.dates.names,
+			minYear:       cfg.DateMinYear,
+			maxYear:       cfg.DateMaxYear,
+			pivot:         cfg.TwoDigitYearPivot,
+			strict:        cfg.StrictDates,
+		},
+		years:       recentYearMatch{referenceYear: cfg.ReferenceYear, window: cfg.RecentYearWindow},
+		breach:      cfg.BreachFilter,
+		passphrases: !cfg.DisablePassphrases,
+	}
+	if cfg.Dictionaries != nil {
+		rd := make(map[string]Dictionary, len(cfg.Dictionaries))
+		for name, d := range cfg.Dictionaries {
+			rd[name] = d
+		}
+		om.dm = defaults.dm.with(rd)
+	}
+	om.dm.normalization = cfg.Normalization
+	om.dm.foldDiacritics = cfg.FoldDiacritics
+	if cfg.Graphs != nil {
+		om.graphs = append([]*adjacency.Graph(nil), cfg.Graphs...)
+	}
+	if cfg.L33tTable != nil {
+		om.l33tTable = copyL33tTable(cfg.L33tTable)
+	}
+	if cfg.Regexes != nil {
+		om.regexes = append([]NamedRegexp(nil), cfg.Regexes...)
+	}
+	if cfg.LayoutSwitches != nil {
+		om.layoutSwitches = newLayoutSwitches(cfg.LayoutSwitches)
+	}
+	om.scorer = scoring.Scorer{ReferenceYear: cfg.ReferenceYear, Regexes: regexGuesses(om.regexes)}
+	if cfg.DateNames != nil {
+		om.dates.names = newDateNameIndex(cfg.DateNames)
+	}
+	return om
+}
+
+// Scorer returns the scorer of the matches of om: it compares dates to its reference year
+// and estimates regex matches with the guesses of its regexes.
+func (om *Omnimatcher) Scorer() scoring.Scorer {
+	return om.scorer
+}
+
+// Regexes returns the regexes matched by om, besides the recent years.
+func (om *Omnimatcher) Regexes() []NamedRegexp {
+	return append([]NamedRegexp(nil), om.regexes...)
+}
+
+// Matches returns every match found in password, sorted by position.
+// userInputs are matched as an additional "user_inputs" dictionary.
+func (om *Omnimatcher) Matches(password string, userInputs []string) []*match.Match {
+	matches, _ := om.MatchesContext(context.Background(), password, userInputs)
+	return matches
+}
+
+// contextMatcher is implemented by the matchers, so that they can be interrupted on
+// long passwords.
+type contextMatcher interface {
+	matchesContext(ctx context.Context, password string) ([]*match.Match, error)
+}
+
+// MatchesContext is like Matches but gives up with ctx.Err() once ctx is done.
+func (om *Omnimatcher) MatchesContext(ctx context.Context, password string, userInputs []string) (matches []*match.Match, err error) {
+	dictMatcher := om.dm.withDict(userInputsDictionary, BuildRankedDict(userInputs))
 
 	matchers := []match.Matcher{
 		dictMatcher,
 		reverseDictionnaryMatch{dm: dictMatcher},
-		l33tMatch{dm: dictMatcher, table: l33tTable},
-		spatialMatch{graphs: defaultGraphs},
-		repeatMatch{},
+		l33tMatch{dm: dictMatcher, table: om.l33tTable},
+		spatialMatch{graphs: om.graphs},
+		repeatMatch{om: om},
 		sequenceMatch{},
-		regexpMatch{regexes: defaultRegexpMatch},
-		dateMatch{},
+		regexpMatch{regexes: om.regexes},
+		om.years,
+		om.dates,
+		breachedMatch{filter: om.breach},
+	}
+	if len(om.layoutSwitches) > 0 {
+		matchers = append(matchers, layoutSwitchMatch{dm: dictMatcher, switches: om.layoutSwitches})
+	}
+	if om.passphrases {
+		matchers = append(matchers, passphraseMatch{dm: dictMatcher})
 	}
 
 	for _, m := range matchers {
-		matches = append(matches, m.Matches(password)...)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if cm, ok := m.(contextMatcher); ok {
+			found, err := cm.matchesContext(ctx, password)
+			if err != nil {
+				return nil, err
+			}
+			matches = append(matches, found...)
+		} else {
+			matches = append(matches, m.Matches(password)...)
+		}
 	}
 	match.Sort(matches)
-	return matches
+	return matches, nil
 }
 
+// Omnimatch returns every match found in password using the default Omnimatcher.
+func Omnimatch(password string, userInputs []string) (matches []*match.Match) {
+	return Default().Matches(password, userInputs)
+}
+
+const userInputsDictionary = "user_inputs"
+
 var (
-	defaultRankedDictionnaries = loadDefaultDictionnaries()
-	defaultGraphs              = loadDefaultAdjacencyGraphs()
-	defaultRegexpMatch         = []struct {
-		Name   string
-		Regexp *regexp.Regexp
-	}{
-		{
-			Name:   "recent_year",
-			Regexp: regexp.MustCompile(`19\d\d|200\d|201\d`),
-		},
-	}
 	l33tTable = map[string][]string{
 		"a": {"4", "@"},
 		"b": {"8"},
@@ -57,22 +268,59 @@ var (
 	}
 )
 
-func loadDefaultDictionnaries() dictionaryMatch {
-	rd := make(map[string]rankedDictionnary)
-	for n, list := range frequency.FrequencyLists {
-		rd[n] = buildRankedDict(list)
-	}
-	return dictionaryMatch{
-		rankedDictionaries: rd,
+// ExtendedL33tTable adds to the default l33t table substitutions spanning several
+// characters, like |< for k or ph for f, and look-alike letters of other scripts, like the
+// cyrillic о for o. Use it with Config.L33tTable; it must not be modified.
+var ExtendedL33tTable = map[string][]string{
+	"a": {"4", "@", "/\\", "/-\\", "^", "α", "а"},
+	"b": {"8", "|3", "ß"},
+	"c": {"(", "{", "[", "<", "¢", "©", "с"},
+	"d": {"|)", "|>"},
+	"e": {"3", "€", "е"},
+	"f": {"ph", "|="},
+	"g": {"6", "9"},
+	"h": {"#", "|-|", "]-[", "}{", "н"},
+	"i": {"1", "!", "|", "¡", "і"},
+	"k": {"|<", "|{"},
+	"l": {"1", "|", "7", "£"},
+	"m": {"|\\/|", "/\\/\\"},
+	"n": {"|\\|", "/\\/"},
+	"o": {"0", "()", "ø", "о", "ο"},
+	"p": {"|*", "р"},
+	"r": {"|2", "®"},
+	"s": {"$", "5", "§"},
+	"t": {"+", "7"},
+	"u": {"|_|", "µ"},
+	"v": {"\\/"},
+	"w": {"vv", "\\/\\/", "\\^/"},
+	"x": {"%", "><", "×", "х"},
+	"y": {"¥", "у"},
+	"z": {"2"},
+}
+
+func copyL33tTable(table map[string][]string) map[string][]string {
+	t := make(map[string][]string, len(table))
+	for k, v := range table {
+		t[k] = append([]string(nil), v...)
 	}
+	return t
+}
+
+func loadDefaultDictionnaries() dictionaryMatch {
+	return newDictionaryMatch(PackedDictionaries(frequency.Default()))
 }
 
 func loadDefaultAdjacencyGraphs() []*adjacency.Graph {
 	return []*adjacency.Graph{
-		adjacency.Graphs["qwerty"],
-		adjacency.Graphs["dvorak"],
-		adjacency.Graphs["keypad"],
-		adjacency.Graphs["mac_keypad"],
+		adjacency.Get("qwerty"),
+		adjacency.Get("dvorak"),
+		adjacency.Get("azerty"),
+		adjacency.Get("qwertz"),
+		adjacency.Get("colemak"),
+		adjacency.Get("workman"),
+		adjacency.Get("jcuken"),
+		adjacency.Get("keypad"),
+		adjacency.Get("mac_keypad"),
 	}
 
 }
@@ -1,10 +1,12 @@
 package matching
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/akara-io/zxcvbn/match"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"regexp"
 	"testing"
 )
 
@@ -51,12 +53,36 @@ func TestOmnimatch(t *testing.T) {
 			Graph:        "qwerty",
 			Turns:        1,
 			ShiftedCount: 0},
+		{
+			Pattern:      "spatial",
+			I:            2,
+			J:            4,
+			Token:        "cde",
+			Graph:        "azerty",
+			Turns:        1,
+			ShiftedCount: 0},
+		{
+			Pattern:      "spatial",
+			I:            2,
+			J:            4,
+			Token:        "cde",
+			Graph:        "qwertz",
+			Turns:        1,
+			ShiftedCount: 0},
 	}, matches)
 
 	password = "qwER43@!"
 	matches = Omnimatch(password, nil)
 	json.NewEncoder(os.Stdout).Encode(matches)
 	assert.Equal(t, []*match.Match{
+		{
+			Pattern:      "spatial",
+			I:            0,
+			J:            5,
+			Token:        "qwER43",
+			Graph:        "qwertz",
+			Turns:        3,
+			ShiftedCount: 2},
 		{
 			Pattern:      "spatial",
 			I:            0,
@@ -86,6 +112,14 @@ func TestOmnimatch(t *testing.T) {
 			Reversed:       false,
 			L33t:           true,
 			Sub:            map[string]string{"4": "a"}},
+		{
+			Pattern:      "spatial",
+			I:            2,
+			J:            5,
+			Token:        "ER43",
+			Graph:        "azerty",
+			Turns:        3,
+			ShiftedCount: 4},
 		{
 			Pattern:        "dictionary",
 			I:              3,
@@ -97,6 +131,14 @@ func TestOmnimatch(t *testing.T) {
 			Reversed:       false,
 			L33t:           true,
 			Sub:            map[string]string{"3": "e", "4": "a"}},
+		{
+			Pattern:      "spatial",
+			I:            3,
+			J:            7,
+			Token:        "R43@!",
+			Graph:        "workman",
+			Turns:        2,
+			ShiftedCount: 3},
 		{
 			Pattern:       "sequence",
 			I:             4,
@@ -113,6 +155,14 @@ func TestOmnimatch(t *testing.T) {
 			Graph:        "dvorak",
 			Turns:        1,
 			ShiftedCount: 2},
+		{
+			Pattern:      "spatial",
+			I:            4,
+			J:            7,
+			Token:        "43@!",
+			Graph:        "colemak",
+			Turns:        1,
+			ShiftedCount: 2},
 	}, matches)
 
 	password = "eheuczkqyq"
@@ -140,3 +190,40 @@ func TestOmnimatch(t *testing.T) {
 			L33t:           false},
 	}, matches)
 }
+
+func TestOmnimatcherMatchesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Default().MatchesContext(ctx, "r0sebudmaelstrom11/20/91aaaa", nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	matches, err := Default().MatchesContext(context.Background(), "r0sebudmaelstrom11/20/91aaaa", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Omnimatch("r0sebudmaelstrom11/20/91aaaa", nil), matches)
+}
+
+func TestMatchersContext(t *testing.T) {
+	om := Default()
+	matchers := map[string]match.Matcher{
+		"dictionary": om.dm,
+		"reverse":    reverseDictionnaryMatch{dm: om.dm},
+		"l33t":       l33tMatch{dm: om.dm, table: om.l33tTable},
+		"spatial":    spatialMatch{graphs: om.graphs},
+		"repeat":     repeatMatch{om: om},
+		"sequence":   sequenceMatch{},
+		"regex":      regexpMatch{regexes: []NamedRegexp{{Name: "digits", Regexp: regexp.MustCompile(`\d+`)}}},
+		"recentYear": om.years,
+		"date":       om.dates,
+		"passphrase": passphraseMatch{dm: om.dm},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for name, m := range matchers {
+		cm, ok := m.(contextMatcher)
+		if !assert.True(t, ok, name) {
+			continue
+		}
+		_, err := cm.matchesContext(ctx, "r0sebudmaelstrom11/20/91aaaa15march1987abcdqwerty")
+		assert.ErrorIs(t, err, context.Canceled, name)
+	}
+}
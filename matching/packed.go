@@ -0,0 +1,100 @@
+package matching
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
+
+// PackedDictionaries returns the lists of d keyed by name, for use in Config.Dictionaries.
+// They are matched against in place, without building a map or a trie.
+func PackedDictionaries(d *packed.Dict) map[string]Dictionary {
+	names := d.Names()
+	res := make(map[string]Dictionary, len(names))
+	for i, name := range names {
+		res[name] = packedList{d: d, list: i, name: name}
+	}
+	return res
+}
+
+// packedList is the Dictionary of one list of a packed.Dict.
+type packedList struct {
+	d    *packed.Dict
+	list int
+	name string
+}
+
+func (pl packedList) Rank(word string) (int, bool) {
+	for _, e := range pl.d.Lookup(word) {
+		if e.List == pl.list {
+			return e.Rank, true
+		}
+	}
+	return 0, false
+}
+
+func (pl packedList) Each(fn func(word string, rank int) bool) {
+	pl.d.Each(func(word string, entries []packed.Entry) bool {
+		for _, e := range entries {
+			if e.List == pl.list {
+				return fn(word, e.Rank)
+			}
+		}
+		return true
+	})
+}
+
+// packedIndex is the wordIndex of the lists of a packed.Dict.
+type packedIndex struct {
+	d         *packed.Dict
+	listNames []string
+}
+
+func newPackedIndex(d *packed.Dict) packedIndex {
+	return packedIndex{d: d, listNames: d.Names()}
+}
+
+func (pi packedIndex) names() []string {
+	return pi.listNames
+}
+
+func (pi packedIndex) walk(s string, fn func(end int, word string, entries []indexEntry)) {
+	var word strings.Builder
+	var entries []indexEntry
+	r := pi.d.Root()
+	for end := 0; end < len(s); {
+		c, size := utf8.DecodeRuneInString(s[end:])
+		end += size
+		var ok bool
+		if r, ok = r.Next(c); !ok {
+			return
+		}
+		word.WriteRune(unicode.ToLower(c))
+		if found := r.Entries(); len(found) > 0 {
+			entries = entries[:0]
+			for _, e := range found {
+				entries = append(entries, indexEntry{dict: int32(e.List), rank: int32(e.Rank)})
+			}
+			fn(end, word.String(), entries)
+		}
+	}
+}
+
+func (pi packedIndex) root() wordPos {
+	return wordPos{rng: pi.d.Root()}
+}
+
+func (pi packedIndex) next(p wordPos, r rune) (wordPos, bool) {
+	rng, ok := p.rng.Next(r)
+	return wordPos{rng: rng}, ok
+}
+
+func (pi packedIndex) wordEntries(p wordPos) []indexEntry {
+	var entries []indexEntry
+	for _, e := range p.rng.Entries() {
+		entries = append(entries, indexEntry{dict: int32(e.List), rank: int32(e.Rank)})
+	}
+	return entries
+}
@@ -0,0 +1,167 @@
+package matching
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/akara-io/zxcvbn/match"
+)
+
+// passphraseSeparators are the characters the words of a passphrase may be joined with.
+// Words joined in camel case, like CorrectHorse, have no separator.
+const passphraseSeparators = " -._"
+
+// passphraseMatch finds runs of at least two dictionary words joined by the same
+// separator, like correct-horse-battery-staple, or in camel case, like CorrectHorseBattery.
+// Each word fills a run of letters of the password: the words of bigcorrect-horse are
+// horse alone, not big and correct.
+type passphraseMatch struct {
+	dm dictionaryMatch
+}
+
+func (pm passphraseMatch) Matches(password string) []*match.Match {
+	matches, _ := pm.matchesContext(context.Background(), password)
+	return matches
+}
+
+func (pm passphraseMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
+	found, err := pm.dm.matchesContext(ctx, password)
+	if err != nil {
+		return nil, err
+	}
+	// the most common word spelled by each token
+	words := make(map[[2]int]*match.Match)
+	for _, m := range found {
+		key := [2]int{m.I, m.J}
+		if w, ok := words[key]; !ok || m.Rank < w.Rank {
+			words[key] = m
+		}
+	}
+	word := func(s span) *match.Match {
+		return words[[2]int{s.start, s.end - 1}]
+	}
+
+	var matches []*match.Match
+	add := func(run []*match.Match, sep string) {
+		if len(run) >= 2 {
+			matches = append(matches, newPassphraseMatch(password, run, sep))
+		}
+	}
+
+	segments := letterRuns(password)
+
+	// words joined by separators
+	var run []*match.Match
+	var sep string
+	for k, s := range segments {
+		w := word(s)
+		if w == nil {
+			add(run, sep)
+			run = nil
+			continue
+		}
+		if len(run) > 0 {
+			between := password[segments[k-1].end:s.start]
+			switch {
+			case len(between) != 1 || strings.IndexByte(passphraseSeparators, between[0]) < 0:
+				add(run, sep)
+				run = nil
+			case len(run) == 1:
+				sep = between
+			case between != sep:
+				// the previous word starts a run with the new separator
+				add(run, sep)
+				run, sep = run[len(run)-1:], between
+			}
+		}
+		run = append(run, w)
+	}
+	add(run, sep)
+
+	// words joined in camel case
+	for _, s := range segments {
+		run = nil
+		for _, part := range camelCaseParts(password, s) {
+			w := word(part)
+			if w == nil || !camelCaseWord(w.Token) {
+				add(run, "")
+				run = nil
+				continue
+			}
+			run = append(run, w)
+		}
+		add(run, "")
+	}
+
+	match.Sort(matches)
+	return matches, nil
+}
+
+func newPassphraseMatch(password string, words []*match.Match, sep string) *match.Match {
+	first, last := words[0], words[len(words)-1]
+	m := &match.Match{
+		Pattern:   "passphrase",
+		I:         first.I,
+		J:         last.J,
+		Token:     password[first.I : last.J+1],
+		Separator: sep,
+		WordCount: len(words),
+	}
+	for _, w := range words {
+		word := *w
+		m.BaseMatches = append(m.BaseMatches, &word)
+	}
+	return m
+}
+
+// span is the part of a string from the byte start to the byte end, excluded.
+type span struct {
+	start, end int
+}
+
+// letterRuns returns the maximal runs of letters of password.
+func letterRuns(password string) []span {
+	var runs []span
+	start := -1
+	for i, r := range password {
+		letter := unicode.IsLetter(r) || unicode.IsMark(r)
+		if letter && start < 0 {
+			start = i
+		} else if !letter && start >= 0 {
+			runs = append(runs, span{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		runs = append(runs, span{start, len(password)})
+	}
+	return runs
+}
+
+// camelCaseParts splits the letters of s before each uppercase letter following a
+// lowercase one, or returns nil if there is no such letter.
+func camelCaseParts(password string, s span) []span {
+	var parts []span
+	start := s.start
+	prevLower := false
+	for i, r := range password[s.start:s.end] {
+		if unicode.IsUpper(r) && prevLower {
+			parts = append(parts, span{start, s.start + i})
+			start = s.start + i
+		}
+		prevLower = unicode.IsLower(r)
+	}
+	if parts == nil {
+		return nil
+	}
+	return append(parts, span{start, s.end})
+}
+
+// camelCaseWord reports whether w is a word of camel case: only its first letter may be
+// uppercase, unlike HORSE in CorrectHORSE.
+func camelCaseWord(w string) bool {
+	_, size := utf8.DecodeRuneInString(w)
+	return strings.ToLower(w[size:]) == w[size:]
+}
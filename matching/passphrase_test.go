@@ -0,0 +1,84 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/akara-io/zxcvbn/match"
+)
+
+func Test_passphraseMatch(t *testing.T) {
+	pm := passphraseMatch{dm: newDictionaryMatch(map[string]Dictionary{
+		"d1": RankedDictionary{"correct": 1, "horse": 2, "battery": 3, "staple": 4},
+		"d2": RankedDictionary{"horse": 1, "horses": 6},
+	})}
+	words := func(m *match.Match) []string {
+		var res []string
+		for _, w := range m.BaseMatches {
+			res = append(res, w.Token)
+		}
+		return res
+	}
+
+	for _, tt := range []struct {
+		password string
+		token    string
+		i        int
+		sep      string
+		words    []string
+	}{
+		{"correct-horse-battery-staple", "correct-horse-battery-staple", 0, "-", []string{"correct", "horse", "battery", "staple"}},
+		{"correct horse", "correct horse", 0, " ", []string{"correct", "horse"}},
+		{"Correct.Horse.Battery", "Correct.Horse.Battery", 0, ".", []string{"Correct", "Horse", "Battery"}},
+		{"!!correct_horse99", "correct_horse", 2, "_", []string{"correct", "horse"}},
+		{"CorrectHorseBattery", "CorrectHorseBattery", 0, "", []string{"Correct", "Horse", "Battery"}},
+		{"xx1correctHorse", "correctHorse", 3, "", []string{"correct", "Horse"}},
+		// words that aren't whole are left out
+		{"xcorrect-horse-battery", "horse-battery", 9, "-", []string{"horse", "battery"}},
+		{"XyzCorrectHorse", "CorrectHorse", 3, "", []string{"Correct", "Horse"}},
+	} {
+		t.Run(tt.password, func(t *testing.T) {
+			matches := pm.Matches(tt.password)
+			if assert.Len(t, matches, 1) {
+				m := matches[0]
+				assert.Equal(t, "passphrase", m.Pattern)
+				assert.Equal(t, tt.token, m.Token)
+				assert.Equal(t, tt.i, m.I)
+				assert.Equal(t, tt.i+len(tt.token)-1, m.J)
+				assert.Equal(t, tt.sep, m.Separator)
+				assert.Equal(t, len(tt.words), m.WordCount)
+				assert.Equal(t, tt.words, words(m))
+			}
+		})
+	}
+
+	// the most common reading of each word is kept
+	m := pm.Matches("horse-staple")[0]
+	assert.Equal(t, []int{1, 4}, []int{m.BaseMatches[0].Rank, m.BaseMatches[1].Rank})
+	assert.Equal(t, "d2", m.BaseMatches[0].DictionaryName)
+
+	// separators change between runs, the word between them being shared
+	matches := pm.Matches("correct-horse.battery.staple")
+	if assert.Len(t, matches, 2) {
+		assert.Equal(t, "correct-horse", matches[0].Token)
+		assert.Equal(t, "horse.battery.staple", matches[1].Token)
+	}
+	// a word that isn't in the dictionaries ends a run
+	matches = pm.Matches("correct horse zebra battery staple")
+	if assert.Len(t, matches, 2) {
+		assert.Equal(t, "correct horse", matches[0].Token)
+		assert.Equal(t, "battery staple", matches[1].Token)
+	}
+
+	for _, password := range []string{
+		"correct",        // a single word
+		"correct--horse", // several separators
+		"correct+horse",  // not a separator
+		"correcthorse",   // no separator
+		"CorrectHORSE",   // not camel case
+		"correct1horse",  // digits aren't separators
+	} {
+		assert.Empty(t, pm.Matches(password), password)
+	}
+}
@@ -1,21 +1,31 @@
 package matching
 
 import (
+	"context"
 	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/akara-io/zxcvbn/match"
+	"github.com/akara-io/zxcvbn/scoring"
 )
 
 type regexpMatch struct {
-	regexes []struct {
-		Name   string
-		Regexp *regexp.Regexp
-	}
+	regexes []NamedRegexp
 }
 
 func (r regexpMatch) Matches(password string) []*match.Match {
+	matches, _ := r.matchesContext(context.Background(), password)
+	return matches
+}
+
+func (r regexpMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
 	var matches []*match.Match
 	for _, rx := range r.regexes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		for _, indexes := range rx.Regexp.FindAllStringIndex(password, -1) {
 			token := password[indexes[0]:indexes[1]]
 			matches = append(matches, &match.Match{
@@ -28,5 +38,118 @@ func (r regexpMatch) Matches(password string) []*match.Match {
 		}
 	}
 	match.Sort(matches)
+	return matches, nil
+}
+
+// DefaultRecentYearWindow is the default number of years before the reference year
+// matched as recent years.
+const DefaultRecentYearWindow = 120
+
+// recentYearMatch reports the years close to the reference year as recent_year regex
+// matches: from window years before it to scoring.MinYearSpace years after it, the
+// years that close being all scored alike.
+type recentYearMatch struct {
+	// referenceYear is the year recent years are close to; zero means scoring.ReferenceYear.
+	referenceYear int
+	// window is the number of years before referenceYear that are recent; zero means
+	// DefaultRecentYearWindow and a negative window matches no year.
+	window int
+}
+
+func (r recentYearMatch) Matches(password string) []*match.Match {
+	matches, _ := r.matchesContext(context.Background(), password)
 	return matches
 }
+
+func (r recentYearMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
+	if r.window < 0 {
+		return nil, nil
+	}
+	referenceYear, window := r.referenceYear, r.window
+	if referenceYear == 0 {
+		referenceYear = scoring.ReferenceYear
+	}
+	if window == 0 {
+		window = DefaultRecentYearWindow
+	}
+	rx := recentYearRegexp(referenceYear-window, referenceYear+scoring.MinYearSpace)
+	if rx == nil {
+		return nil, nil
+	}
+	return regexpMatch{regexes: []NamedRegexp{{Name: "recent_year", Regexp: rx}}}.matchesContext(ctx, password)
+}
+
+// recentYearRegexps caches the regexps returned by recentYearRegexp.
+var recentYearRegexps sync.Map // [2]int -> *regexp.Regexp
+
+// recentYearRegexp returns a regexp matching the four-digit years from from to to, like
+// 19[3-9]\d|20[0-3]\d for 1930 to 2039, or nil if there are none.
+func recentYearRegexp(from, to int) *regexp.Regexp {
+	if from < 1000 {
+		from = 1000
+	}
+	if to > 9999 {
+		to = 9999
+	}
+	if from > to {
+		return nil
+	}
+	key := [2]int{from, to}
+	if rx, ok := recentYearRegexps.Load(key); ok {
+		return rx.(*regexp.Regexp)
+	}
+	lo, hi := strconv.Itoa(from), strconv.Itoa(to)
+	rx := regexp.MustCompile(strings.Join(numberRange(lo, hi), "|"))
+	recentYearRegexps.Store(key, rx)
+	return rx
+}
+
+// numberRange returns the alternatives of a regular expression matching the numbers
+// between lo and hi, both written with the same number of digits.
+func numberRange(lo, hi string) []string {
+	if lo == hi {
+		return []string{lo}
+	}
+	if lo[0] == hi[0] {
+		alts := numberRange(lo[1:], hi[1:])
+		for i, a := range alts {
+			alts[i] = lo[:1] + a
+		}
+		return alts
+	}
+	// lo and hi differ from their first digit: the numbers from lo to the end of its first
+	// digit, the first digits in between followed by any digits, then the numbers from the
+	// start of the first digit of hi to hi.
+	n := len(lo) - 1
+	first, last := lo[0], hi[0]
+	var alts, lastAlts []string
+	if lo[1:] != strings.Repeat("0", n) {
+		for _, a := range numberRange(lo[1:], strings.Repeat("9", n)) {
+			alts = append(alts, lo[:1]+a)
+		}
+		first++
+	}
+	if hi[1:] != strings.Repeat("9", n) {
+		for _, a := range numberRange(strings.Repeat("0", n), hi[1:]) {
+			lastAlts = append(lastAlts, hi[:1]+a)
+		}
+		last--
+	}
+	if first <= last {
+		alts = append(alts, digitClass(first, last)+strings.Repeat(`\d`, n))
+	}
+	return append(alts, lastAlts...)
+}
+
+// digitClass returns a regular expression matching the digits from first to last.
+func digitClass(first, last byte) string {
+	switch {
+	case first == last:
+		return string(first)
+	case first == '0' && last == '9':
+		return `\d`
+	case first+1 == last:
+		return "[" + string(first) + string(last) + "]"
+	}
+	return "[" + string(first) + "-" + string(last) + "]"
+}
@@ -1,13 +1,31 @@
 package matching
 
 import (
+	"regexp"
+	"testing"
+
 	"github.com/akara-io/zxcvbn/match"
+	"github.com/akara-io/zxcvbn/scoring"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
 func TestRegexpMatching(t *testing.T) {
-	rm := regexpMatch{regexes: defaultRegexpMatch}
+	rm := regexpMatch{regexes: []NamedRegexp{{Name: "digits", Regexp: regexp.MustCompile(`\d+`)}}}
+	assert.Equal(t, []*match.Match{
+		{
+			Pattern:   "regex",
+			Token:     "1922",
+			I:         2,
+			J:         5,
+			RegexName: "digits",
+		},
+	},
+		rm.Matches("ab1922"),
+	)
+}
+
+func TestRecentYearMatching(t *testing.T) {
+	ym := recentYearMatch{referenceYear: 2017}
 	assert.Equal(t, []*match.Match{
 		{
 			Pattern:   "regex",
@@ -17,7 +35,7 @@ func TestRegexpMatching(t *testing.T) {
 			RegexName: "recent_year",
 		},
 	},
-		rm.Matches("1922"),
+		ym.Matches("1922"),
 	)
 
 	assert.Equal(t, []*match.Match{
@@ -29,6 +47,67 @@ func TestRegexpMatching(t *testing.T) {
 			RegexName: "recent_year",
 		},
 	},
-		rm.Matches("2017"),
+		ym.Matches("2017"),
 	)
+
+	// recent years span from the window before the reference year to
+	// scoring.MinYearSpace years after it
+	for _, tt := range []struct {
+		referenceYear, window int
+		recent, old           []string
+	}{
+		{2017, 0, []string{"1897", "1922", "2017", "2037"}, []string{"1896", "2038", "2099"}},
+		{2019, 0, []string{"1900", "2019", "2039"}, []string{"1898", "2040"}},
+		{2025, 0, []string{"1905", "2021", "2024", "2029", "2045"}, []string{"1904", "2046"}},
+		{2040, 0, []string{"1920", "2060"}, []string{"1919", "2061"}},
+		{2026, 50, []string{"1976", "1999", "2046"}, []string{"1975", "1922", "2047"}},
+		{1990, 10, []string{"1980", "1999", "2010"}, []string{"1979", "2011"}},
+		{2026, -1, nil, []string{"1999", "2026"}},
+	} {
+		ym := recentYearMatch{referenceYear: tt.referenceYear, window: tt.window}
+		for _, year := range tt.recent {
+			matches := ym.Matches("x" + year + "!")
+			if assert.Len(t, matches, 1, "%s, %+v", year, ym) {
+				assert.Equal(t, year, matches[0].Token)
+				assert.Equal(t, 1, matches[0].I)
+			}
+		}
+		for _, year := range tt.old {
+			assert.Empty(t, ym.Matches(year), "%s, %+v", year, ym)
+		}
+	}
+
+	// years are found like the regexp used to: leftmost and without overlapping
+	assert.Equal(t, []string{"2019", "2020"}, tokens(recentYearMatch{referenceYear: 2020}.Matches("2019202020")))
+
+	// the zero value follows scoring.ReferenceYear
+	defer func(year int) { scoring.ReferenceYear = year }(scoring.ReferenceYear)
+	scoring.ReferenceYear = 2100
+	assert.Len(t, recentYearMatch{}.Matches("2110"), 1)
+	assert.Empty(t, recentYearMatch{}.Matches("1950"))
+}
+
+func Test_recentYearRegexp(t *testing.T) {
+	for _, tt := range []struct {
+		from, to int
+		want     string
+	}{
+		{1900, 2019, `19\d\d|20[01]\d`},
+		{1926, 2046, `192[6-9]|19[3-9]\d|20[0-3]\d|204[0-6]`},
+		{1999, 2000, `1999|2000`},
+		{2000, 2009, `200\d`},
+		{2020, 2020, `2020`},
+		{1000, 9999, `[1-9]\d\d\d`},
+		{990, 1001, `100[01]`},
+	} {
+		assert.Equal(t, tt.want, recentYearRegexp(tt.from, tt.to).String(), "%d-%d", tt.from, tt.to)
+	}
+	assert.Nil(t, recentYearRegexp(2050, 2040))
+}
+
+func tokens(matches []*match.Match) (tokens []string) {
+	for _, m := range matches {
+		tokens = append(tokens, m.Token)
+	}
+	return tokens
 }
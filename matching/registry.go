@@ -0,0 +1,160 @@
+package matching
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/akara-io/zxcvbn/adjacency"
+	"github.com/akara-io/zxcvbn/frequency/packed"
+)
+
+var (
+	// registryMu serializes the updates of defaultOmnimatcher.
+	registryMu         sync.Mutex
+	defaultOmnimatcher atomic.Pointer[Omnimatcher]
+	defaultOnce        sync.Once
+)
+
+// loadDefaults loads the default dictionaries and keyboard graphs.
+func loadDefaults() {
+	defaultOnce.Do(func() {
+		defaultOmnimatcher.Store(&Omnimatcher{
+			dm:          loadDefaultDictionnaries(),
+			graphs:      loadDefaultAdjacencyGraphs(),
+			l33tTable:   l33tTable,
+			dates:       dateMatch{names: defaultDateNames},
+			passphrases: true,
+		})
+	})
+}
+
+// Default returns the Omnimatcher used by Omnimatch: the package defaults and the
+// dictionaries added by RegisterDictionary. The defaults are loaded on first use.
+func Default() *Omnimatcher {
+	loadDefaults()
+	return defaultOmnimatcher.Load()
+}
+
+// SetDefault replaces the Omnimatcher used by Omnimatch, on which the Omnimatchers
+// created afterwards are based, and returns the previous one. Setting back an
+// Omnimatcher returned by Default undoes the registrations made since, like in tests.
+// om must not be nil.
+func SetDefault(om *Omnimatcher) *Omnimatcher {
+	loadDefaults()
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return defaultOmnimatcher.Swap(om)
+}
+
+// Preload loads the default dictionaries and keyboard graphs, which otherwise
+// happens on first use.
+func Preload() {
+	loadDefaults()
+}
+
+// RegisterDictionary adds a dictionary of words, ranked by their order, to the defaults
+// used by Omnimatch and by the Omnimatchers created afterwards. It replaces any default
+// dictionary with the same name. Matches found in the dictionary report name as their
+// DictionaryName.
+func RegisterDictionary(name string, words []string) error {
+	if name == "" || name == userInputsDictionary {
+		return fmt.Errorf("matching: invalid dictionary name %q", name)
+	}
+	d := BuildRankedDict(words)
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	om := *Default()
+	om.dm = om.dm.withDict(name, d)
+	defaultOmnimatcher.Store(&om)
+	return nil
+}
+
+// RegisterPackedDictionaries adds the lists of d, like the lists of a language of
+// frequency/lang, to the defaults used by Omnimatch and by the Omnimatchers created
+// afterwards. They replace any default dictionary with the same name and are matched in
+// place, so d must not be closed afterwards.
+func RegisterPackedDictionaries(d *packed.Dict) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	om := *Default()
+	rd := make(map[string]Dictionary, len(om.dm.rankedDictionaries))
+	for name, dict := range om.dm.rankedDictionaries {
+		rd[name] = dict
+	}
+	for name, dict := range PackedDictionaries(d) {
+		rd[name] = dict
+	}
+	om.dm = om.dm.with(rd)
+	defaultOmnimatcher.Store(&om)
+}
+
+// RegisterKeyboardGraph adds g to the keyboard graphs used by Omnimatch and by the
+// Omnimatchers created afterwards, replacing any default graph with the same name.
+// g is also registered in the adjacency package, so that its spatial matches are
+// scored with its own number of keys and average degree.
+func RegisterKeyboardGraph(g *adjacency.Graph) {
+	adjacency.Register(g)
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	om := *Default()
+	graphs := make([]*adjacency.Graph, 0, len(om.graphs)+1)
+	for _, graph := range om.graphs {
+		if graph.Name != g.Name {
+			graphs = append(graphs, graph)
+		}
+	}
+	om.graphs = append(graphs, g)
+	defaultOmnimatcher.Store(&om)
+}
+
+// RegisterLayoutSwitch adds s to the keyboard layout switches used by Omnimatch and by
+// the Omnimatchers created afterwards, replacing any switch between the same layouts.
+// It returns an error if the characters of the layouts can't be mapped from key to key.
+func RegisterLayoutSwitch(s LayoutSwitch) error {
+	switches := newLayoutSwitches([]LayoutSwitch{s})
+	if len(switches) == 0 {
+		if s.Typed == nil || s.Intended == nil {
+			return fmt.Errorf("matching: invalid layout switch")
+		}
+		return fmt.Errorf("matching: can't switch from layout %s to %s", s.Typed.Name, s.Intended.Name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	om := *Default()
+	layoutSwitches := make([]layoutSwitch, 0, len(om.layoutSwitches)+1)
+	for _, ls := range om.layoutSwitches {
+		if ls.Typed.Name != s.Typed.Name || ls.Intended.Name != s.Intended.Name {
+			layoutSwitches = append(layoutSwitches, ls)
+		}
+	}
+	om.layoutSwitches = append(layoutSwitches, switches...)
+	defaultOmnimatcher.Store(&om)
+	return nil
+}
+
+// RegisterRegex adds r to the regexes used by Omnimatch and by the Omnimatchers created
+// afterwards, replacing any default regex with the same name. Its matches are estimated
+// with r.Guesses by the Scorer of those Omnimatchers.
+func RegisterRegex(r NamedRegexp) error {
+	if r.Name == "" || r.Name == "recent_year" || r.Regexp == nil {
+		return fmt.Errorf("matching: invalid regex %q", r.Name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	om := *Default()
+	regexes := make([]NamedRegexp, 0, len(om.regexes)+1)
+	for _, rx := range om.regexes {
+		if rx.Name != r.Name {
+			regexes = append(regexes, rx)
+		}
+	}
+	om.regexes = append(regexes, r)
+	om.scorer.Regexes = regexGuesses(om.regexes)
+	defaultOmnimatcher.Store(&om)
+	return nil
+}
@@ -0,0 +1,218 @@
+package matching
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akara-io/zxcvbn/adjacency"
+	"github.com/akara-io/zxcvbn/frequency/packed"
+	"github.com/akara-io/zxcvbn/match"
+	"github.com/akara-io/zxcvbn/scoring"
+)
+
+// restoreDefaults sets back the current defaults once t is done, so that its
+// registrations don't leak into the other tests.
+func restoreDefaults(t *testing.T) {
+	saved := Default()
+	t.Cleanup(func() { SetDefault(saved) })
+}
+
+func TestRegisterDictionary(t *testing.T) {
+	restoreDefaults(t)
+	assert.Error(t, RegisterDictionary("", []string{"foo"}))
+	assert.Error(t, RegisterDictionary("user_inputs", []string{"foo"}))
+
+	before := NewOmnimatcher(Config{})
+	require.NoError(t, RegisterDictionary("registry_test", []string{"qzxjkvw", "wvkjxzq"}))
+
+	want := &match.Match{
+		Pattern:        "dictionary",
+		I:              0,
+		J:              6,
+		Token:          "qzxjkvw",
+		MatchedWord:    "qzxjkvw",
+		Rank:           1,
+		DictionaryName: "registry_test",
+	}
+	assert.Contains(t, Omnimatch("qzxjkvw", nil), want)
+	assert.Contains(t, NewOmnimatcher(Config{}).Matches("qzxjkvw", nil), want)
+	assert.Contains(t, DefaultConfig().Dictionaries, "registry_test")
+
+	// Omnimatchers created earlier are unaffected
+	for _, m := range before.Matches("qzxjkvw", nil) {
+		assert.NotEqual(t, "registry_test", m.DictionaryName)
+	}
+}
+
+func TestRegisterPackedDictionaries(t *testing.T) {
+	restoreDefaults(t)
+	d, err := packed.New(packed.Encode(map[string][]string{
+		"registry_packed_words": {"vwkzqxj"},
+	}))
+	require.NoError(t, err)
+
+	before := NewOmnimatcher(Config{})
+	RegisterPackedDictionaries(d)
+	want := &match.Match{
+		Pattern:        "dictionary",
+		I:              0,
+		J:              6,
+		Token:          "vwkzqxj",
+		MatchedWord:    "vwkzqxj",
+		Rank:           1,
+		DictionaryName: "registry_packed_words",
+	}
+	assert.Contains(t, Omnimatch("vwkzqxj", nil), want)
+	assert.Contains(t, DefaultConfig().Dictionaries, "registry_packed_words")
+	assert.Contains(t, DefaultConfig().Dictionaries, "passwords")
+	assert.NotContains(t, before.Matches("vwkzqxj", nil), want)
+}
+
+func TestRegisterKeyboardGraph(t *testing.T) {
+	restoreDefaults(t)
+	g, err := adjacency.ParseLayout(strings.NewReader(`
+name registry_test
+row 0 1! 2@ 3# 4$
+row 1 qQ jJ vV xX
+row 1 zZ kK wW
+`))
+	require.NoError(t, err)
+	before := NewOmnimatcher(Config{})
+	RegisterKeyboardGraph(g)
+
+	// shifted characters are found from the layout
+	want := &match.Match{
+		Pattern:      "spatial",
+		I:            0,
+		J:            3,
+		Token:        "QjvX",
+		Graph:        "registry_test",
+		Turns:        1,
+		ShiftedCount: 2,
+	}
+	assert.Contains(t, Omnimatch("QjvX", nil), want)
+	assert.Contains(t, NewOmnimatcher(Config{}).Matches("QjvX", nil), want)
+	assert.Contains(t, DefaultConfig().Graphs, g)
+	assert.NotContains(t, before.Matches("QjvX", nil), want)
+	assert.Same(t, g, adjacency.Get("registry_test"))
+
+	// matches are scored with the graph's own statistics
+	m := &match.Match{Token: "qjvx", Graph: "registry_test", Turns: 1}
+	assert.Equal(t, float64(len(g.Graph))*g.AverageDegree*3, scoring.SpatialGuesses(m))
+
+	// registering again replaces the graph
+	RegisterKeyboardGraph(adjacency.NewGraph("registry_test", g.Graph))
+	n := 0
+	for _, graph := range Default().graphs {
+		if graph.Name == "registry_test" {
+			n++
+		}
+	}
+	assert.Equal(t, 1, n)
+}
+
+func TestSetDefault(t *testing.T) {
+	saved := Default()
+	require.NoError(t, RegisterDictionary("set_default_test", []string{"qzxjkvw"}))
+	assert.Contains(t, DefaultConfig().Dictionaries, "set_default_test")
+
+	assert.NotSame(t, saved, SetDefault(saved))
+	assert.Same(t, saved, Default())
+	assert.NotContains(t, DefaultConfig().Dictionaries, "set_default_test")
+}
+
+func TestDefaultIsLoadedOnce(t *testing.T) {
+	var wg sync.WaitGroup
+	got := make([]*Omnimatcher, 8)
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				Preload()
+			}
+			got[i] = Default()
+		}(i)
+	}
+	wg.Wait()
+	for _, om := range got {
+		assert.Same(t, got[0], om)
+	}
+	assert.NotEmpty(t, got[0].dm.indexes)
+	assert.NotEmpty(t, got[0].graphs)
+}
+
+func TestRegisterRegex(t *testing.T) {
+	restoreDefaults(t)
+	assert.Error(t, RegisterRegex(NamedRegexp{Regexp: regexp.MustCompile(`x`)}))
+	assert.Error(t, RegisterRegex(NamedRegexp{Name: "recent_year", Regexp: regexp.MustCompile(`x`)}))
+	assert.Error(t, RegisterRegex(NamedRegexp{Name: "registry_test"}))
+
+	before := NewOmnimatcher(Config{})
+	require.NoError(t, RegisterRegex(NamedRegexp{
+		Name:    "registry_test",
+		Regexp:  regexp.MustCompile(`QZX-\d{4}`),
+		Guesses: func(string) float64 { return 1e4 },
+	}))
+
+	want := &match.Match{
+		Pattern:   "regex",
+		I:         1,
+		J:         8,
+		Token:     "QZX-1234",
+		RegexName: "registry_test",
+	}
+	assert.Contains(t, Omnimatch("!QZX-1234", nil), want)
+	om := NewOmnimatcher(Config{})
+	assert.Contains(t, om.Matches("!QZX-1234", nil), want)
+	assert.EqualValues(t, 1e4, om.Scorer().RegexGuesses(want))
+	assert.EqualValues(t, 1e4, Default().Scorer().RegexGuesses(want))
+	assert.Condition(t, func() bool {
+		for _, r := range DefaultConfig().Regexes {
+			if r.Name == "registry_test" {
+				return true
+			}
+		}
+		return false
+	})
+
+	// Omnimatchers created earlier are unaffected
+	for _, m := range before.Matches("!QZX-1234", nil) {
+		assert.NotEqual(t, "registry_test", m.RegexName)
+	}
+	assert.Zero(t, before.Scorer().RegexGuesses(want))
+}
+
+func TestRegisterLayoutSwitch(t *testing.T) {
+	restoreDefaults(t)
+	assert.Error(t, RegisterLayoutSwitch(LayoutSwitch{Typed: adjacency.Get("qwerty")}))
+	assert.Error(t, RegisterLayoutSwitch(LayoutSwitch{Typed: adjacency.Get("qwerty"), Intended: adjacency.Get("keypad")}))
+
+	before := NewOmnimatcher(Config{})
+	require.NoError(t, RegisterLayoutSwitch(LayoutSwitch{Typed: adjacency.Get("qwerty"), Intended: adjacency.Get("azerty")}))
+
+	// the a and q keys, and the z and w keys, are swapped
+	want := &match.Match{
+		Pattern:        "dictionary",
+		I:              0,
+		J:              4,
+		Token:          "qzxjv",
+		MatchedWord:    "awxjv",
+		Rank:           1,
+		DictionaryName: "user_inputs",
+		Layout:         "azerty",
+	}
+	assert.Contains(t, Default().Matches("qzxjv", []string{"awxjv"}), want)
+	assert.Contains(t, NewOmnimatcher(Config{}).Matches("qzxjv", []string{"awxjv"}), want)
+	assert.NotContains(t, before.Matches("qzxjv", []string{"awxjv"}), want)
+	assert.Equal(t, 1, countLayoutSwitches(DefaultConfig().LayoutSwitches, "qwerty", "azerty"))
+
+	// registering again replaces the switch
+	require.NoError(t, RegisterLayoutSwitch(LayoutSwitch{Typed: adjacency.Get("qwerty"), Intended: adjacency.Get("azerty")}))
+	assert.Equal(t, 1, countLayoutSwitches(DefaultConfig().LayoutSwitches, "qwerty", "azerty"))
+}
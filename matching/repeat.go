@@ -1,13 +1,17 @@
 package matching
 
 import (
+	"context"
+
 	"github.com/akara-io/zxcvbn/match"
-	"github.com/akara-io/zxcvbn/scoring"
 
 	"github.com/dlclark/regexp2"
 )
 
-type repeatMatch struct{}
+type repeatMatch struct {
+	// om matches and scores the base token; nil means the default Omnimatcher.
+	om *Omnimatcher
+}
 
 var greedy = regexp2.MustCompile(`(.+)\1+`, 0)
 var lazy = regexp2.MustCompile(`(.+?)\1+`, 0)
@@ -25,8 +29,17 @@ func runeToStringIndex(index int, password string) int {
 	return len(password)
 }
 
-func (repeatMatch) Matches(password string) []*match.Match {
+func (r repeatMatch) Matches(password string) []*match.Match {
+	matches, _ := r.matchesContext(context.Background(), password)
+	return matches
+}
+
+func (r repeatMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
 	var matches []*match.Match
+	om := r.om
+	if om == nil {
+		om = Default()
+	}
 
 	lastIndex := 0
 	for lastIndex < len(password) {
@@ -64,11 +77,14 @@ func (repeatMatch) Matches(password string) []*match.Match {
 		j := runeToStringIndex(rmatch.Index+rmatch.Captures[0].Length-1, password)
 
 		// recursively match and score the base string
-		baseAnalysis := scoring.MostGuessableMatchSequence(
-			baseToken,
-			Omnimatch(baseToken, nil),
-			false,
-		)
+		baseMatches, err := om.MatchesContext(ctx, baseToken, nil)
+		if err != nil {
+			return nil, err
+		}
+		baseAnalysis, err := om.scorer.MostGuessableMatchSequenceContext(ctx, baseToken, baseMatches, false)
+		if err != nil {
+			return nil, err
+		}
 		matches = append(matches, &match.Match{
 			Pattern:     "repeat",
 			I:           i,
@@ -82,5 +98,5 @@ func (repeatMatch) Matches(password string) []*match.Match {
 		lastIndex = j + 1
 
 	}
-	return matches
+	return matches, nil
 }
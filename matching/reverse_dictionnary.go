@@ -1,6 +1,8 @@
 package matching
 
 import (
+	"context"
+
 	"github.com/akara-io/zxcvbn/match"
 )
 
@@ -9,15 +11,23 @@ type reverseDictionnaryMatch struct {
 }
 
 func (rdm reverseDictionnaryMatch) Matches(password string) []*match.Match {
+	matches, _ := rdm.matchesContext(context.Background(), password)
+	return matches
+}
+
+func (rdm reverseDictionnaryMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
 	reversedPassword := reverse(password)
-	matches := rdm.dm.Matches(reversedPassword)
+	matches, err := rdm.dm.matchesContext(ctx, reversedPassword)
+	if err != nil {
+		return nil, err
+	}
 	for _, m := range matches {
 		m.Token = reverse(m.Token)
 		m.Reversed = true
 		m.I, m.J = len(password)-1-m.J, len(password)-1-m.I
 	}
 	match.Sort(matches)
-	return matches
+	return matches, nil
 }
 
 func reverse(input string) string {
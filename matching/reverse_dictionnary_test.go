@@ -9,16 +9,14 @@ import (
 
 func Test_reverseDictionnaryMatch(t *testing.T) {
 	rdm := reverseDictionnaryMatch{
-		dm: dictionaryMatch{
-			rankedDictionaries: map[string]rankedDictionnary{
-				"d1": rankedDictionnary{
-					"123": 1,
-					"321": 2,
-					"456": 3,
-					"654": 4,
-				},
+		dm: newDictionaryMatch(map[string]Dictionary{
+			"d1": RankedDictionary{
+				"123": 1,
+				"321": 2,
+				"456": 3,
+				"654": 4,
 			},
-		},
+		}),
 	}
 
 	password := "0123456789"
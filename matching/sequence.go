@@ -1,7 +1,8 @@
 package matching
 
 import (
-	"regexp"
+	"context"
+	"unicode"
 
 	"github.com/akara-io/zxcvbn/match"
 )
@@ -17,38 +18,86 @@ func abs(a int) int {
 	return a
 }
 
-var reLower = regexp.MustCompile(`^[a-z]+$`)
-var reUpper = regexp.MustCompile(`^[A-Z]+$`)
-var reDigits = regexp.MustCompile(`^\d+$`)
+// sequenceScripts are the scripts sequences are named after, with the number of letters of
+// their usual alphabet.
+var sequenceScripts = []struct {
+	name  string
+	table *unicode.RangeTable
+	space int
+}{
+	{"cyrillic", unicode.Cyrillic, 33},
+	{"greek", unicode.Greek, 24},
+	{"armenian", unicode.Armenian, 38},
+	{"georgian", unicode.Georgian, 33},
+	{"hebrew", unicode.Hebrew, 22},
+	{"arabic", unicode.Arabic, 28},
+	{"devanagari", unicode.Devanagari, 47},
+	{"thai", unicode.Thai, 44},
+	{"hiragana", unicode.Hiragana, 46},
+	{"katakana", unicode.Katakana, 46},
+	{"hangul", unicode.Hangul, 24},
+}
 
-func (sequenceMatch) Matches(password string) []*match.Match {
+// sequenceName returns the name and the number of symbols of the sequence of runes:
+// lower and upper for latin letters, including fullwidth ones, digits for the digits of
+// any script, the script for the letters of the scripts of sequenceScripts and unicode
+// otherwise.
+func sequenceName(runes []rune) (string, int) {
+	all := func(f func(rune) bool) bool {
+		for _, r := range runes {
+			if !f(r) {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case all(func(r rune) bool { return 'a' <= r && r <= 'z' || 'ａ' <= r && r <= 'ｚ' }):
+		return "lower", 26
+	case all(func(r rune) bool { return 'A' <= r && r <= 'Z' || 'Ａ' <= r && r <= 'Ｚ' }):
+		return "upper", 26
+	case all(unicode.IsDigit):
+		return "digits", 10
+	}
+	for _, s := range sequenceScripts {
+		if all(func(r rune) bool { return unicode.Is(s.table, r) }) {
+			return s.name, s.space
+		}
+	}
+	// conservatively stick with roman alphabet size.
+	// (this could be improved)
+	return "unicode", 26
+}
+
+func (sm sequenceMatch) Matches(password string) []*match.Match {
+	matches, _ := sm.matchesContext(context.Background(), password)
+	return matches
+}
+
+func (sequenceMatch) matchesContext(ctx context.Context, password string) ([]*match.Match, error) {
 	matches := []*match.Match{}
-	if len(password) == 1 {
-		return matches
+	// runes and their byte offsets, offsets[len(runes)] being the end of password
+	runes := make([]rune, 0, len(password))
+	offsets := make([]int, 0, len(password)+1)
+	for i, c := range password {
+		runes = append(runes, c)
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(password))
+	if len(runes) <= 1 {
+		return matches, nil
 	}
 
 	update := func(i, j, delta int) {
 		absDelta := abs(delta)
 		if j-i > 1 || absDelta == 1 {
 			if absDelta > 0 && absDelta <= maxDelta {
-				token := password[i : j+1]
-				// conservatively stick with roman alphabet size.
-				// (this could be improved)
-				seqName := "unicode"
-				seqSpace := 26
-				if reLower.MatchString(token) {
-					seqName = "lower"
-				} else if reUpper.MatchString(token) {
-					seqName = "upper"
-				} else if reDigits.MatchString(token) {
-					seqName = "digits"
-					seqSpace = 10
-				}
+				seqName, seqSpace := sequenceName(runes[i : j+1])
 				matches = append(matches, &match.Match{
 					Pattern:       "sequence",
-					I:             i,
-					J:             j,
-					Token:         password[i : j+1],
+					I:             offsets[i],
+					J:             offsets[j+1] - 1,
+					Token:         password[offsets[i]:offsets[j+1]],
 					SequenceName:  seqName,
 					SequenceSpace: seqSpace,
 					Ascending:     delta > 0,
@@ -59,8 +108,11 @@ func (sequenceMatch) Matches(password string) []*match.Match {
 
 	i := 0
 	lastDelta := 0 // null
-	for k := 1; k <= len(password)-1; k++ {
-		delta := int(password[k]) - int(password[k-1])
+	for k := 1; k <= len(runes)-1; k++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		delta := int(runes[k]) - int(runes[k-1])
 		if k == 1 {
 			lastDelta = delta
 		}
@@ -73,6 +125,6 @@ func (sequenceMatch) Matches(password string) []*match.Match {
 		lastDelta = delta
 	}
 
-	update(i, len(password)-1, lastDelta)
-	return matches
+	update(i, len(runes)-1, lastDelta)
+	return matches, nil
 }
@@ -82,6 +82,19 @@ func Test_sequenceMatch_Matches(t *testing.T) {
 		{"zxvt", "lower", false, 26},
 		{"0369", "digits", true, 10},
 		{"97531", "digits", false, 10},
+		{"абвгд", "cyrillic", true, 33},
+		{"ЯЮЭ", "cyrillic", false, 33},
+		{"αβγδ", "greek", true, 24},
+		{"ωψχ", "greek", false, 24},
+		{"あいうえお", "hiragana", true, 46},
+		{"アイウ", "katakana", true, 46},
+		{"１２３４", "digits", true, 10},
+		{"٣٢١", "digits", false, 10},
+		{"ａｂｃ", "lower", true, 26},
+		{"ＸＹＺ", "upper", true, 26},
+		{"אבגד", "hebrew", true, 22},
+		{"àáâã", "unicode", true, 26},
+		{"!\"#$", "unicode", true, 26},
 	}
 	for _, tt := range tests {
 		t.Run(tt.pattern, func(t *testing.T) {
@@ -97,4 +110,15 @@ func Test_sequenceMatch_Matches(t *testing.T) {
 			}}, matches)
 		})
 	}
+
+	// offsets are in bytes, multi-byte characters are never split
+	assert.Equal(t, []*match.Match{{
+		Pattern:       "sequence",
+		Token:         "бвг",
+		I:             3,
+		J:             3 + len("бвг") - 1,
+		Ascending:     true,
+		SequenceName:  "cyrillic",
+		SequenceSpace: 33,
+	}}, s.Matches("a\u00e9бвг!"))
 }
@@ -1,7 +1,8 @@
 package matching
 
 import (
-	"strings"
+	"context"
+	"sync"
 
 	"github.com/akara-io/zxcvbn/adjacency"
 	"github.com/akara-io/zxcvbn/match"
@@ -11,62 +12,112 @@ type spatialMatch struct {
 	graphs []*adjacency.Graph
 }
 
-func (s spatialMatch) Matches(password string) (matches []*match.Match) {
+func (s spatialMatch) Matches(password string) []*match.Match {
+	matches, _ := s.matchesContext(context.Background(), password)
+	return matches
+}
+
+func (s spatialMatch) matchesContext(ctx context.Context, password string) (matches []*match.Match, err error) {
+	// runes and their byte offsets, offsets[len(runes)] being the end of password
+	runes := make([]rune, 0, len(password))
+	offsets := make([]int, 0, len(password)+1)
+	for i, c := range password {
+		runes = append(runes, c)
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(password))
+
 	for _, graph := range s.graphs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if graph.Graph != nil {
-			matches = append(matches, spatialMatchHelper(password, graph)...)
+			matches = append(matches, spatialMatchHelper(password, runes, offsets, graph)...)
 		}
 	}
 	match.Sort(matches)
-	return matches
+	return matches, nil
 }
 
-var shiftedChars = map[string]map[byte]bool{
-	"qwerty": stringToSet(`~!@#$%^&*()_+QWERTYUIOP{}|ASDFGHJKL:"ZXCVBNM<>?`),
-	"dvorak": stringToSet(`~!@#$%^&*()_+QWERTYUIOP{}|ASDFGHJKL:"ZXCVBNM<>?`),
+// Key levels: the position of a character on its key.
+const (
+	levelUnshifted = iota
+	levelShifted
+	levelAltGr
+)
+
+// graphLevels caches the levels of the characters of each graph, as returned by keyLevels.
+var graphLevels sync.Map // *adjacency.Graph -> map[rune]int
+
+// keyLevels returns the level of the characters of graph: 0 when typed alone, 1 with shift
+// and 2 with AltGr. They are found from the keys listed as neighbours, e.g. '2@' for qwerty
+// or 'à0@' for azerty.
+func keyLevels(graph *adjacency.Graph) map[rune]int {
+	if levels, ok := graphLevels.Load(graph); ok {
+		return levels.(map[rune]int)
+	}
+	levels := make(map[rune]int, len(graph.Graph))
+	for _, adjacents := range graph.Graph {
+		for _, key := range adjacents {
+			level := 0
+			for _, c := range key {
+				levels[c] = level
+				level++
+			}
+		}
+	}
+	graphLevels.Store(graph, levels)
+	return levels
 }
 
-func stringToSet(s string) map[byte]bool {
-	set := make(map[byte]bool)
-	for i := 0; i < len(s); i++ {
-		set[s[i]] = true
+// keyLevel returns the level of c on key, or -1 if key does not hold c.
+func keyLevel(key string, c rune) int {
+	level := 0
+	for _, k := range key {
+		if k == c {
+			return level
+		}
+		level++
 	}
-	return set
+	return -1
 }
 
-func spatialMatchHelper(password string, graph *adjacency.Graph) (matches []*match.Match) {
-	shifted := shiftedChars[graph.Name]
+func spatialMatchHelper(password string, runes []rune, offsets []int, graph *adjacency.Graph) (matches []*match.Match) {
+	levels := keyLevels(graph)
 
+	// modified counts the characters typed with a modifier, by level
+	var modified [levelAltGr + 1]int
 	i := 0
-	for i < len(password)-1 {
+	for i < len(runes)-1 {
 		j := i + 1
 		lastDirection := -99
 		turns := 0
-		shiftedCount := 0
-		if shifted[password[i]] {
-			shiftedCount = 1
+		modified = [levelAltGr + 1]int{}
+		if level := levels[runes[i]]; level < len(modified) {
+			modified[level]++
 		}
 
 		for {
-			prevChar := password[j-1]
+			prevChar := runes[j-1]
 			found := false
 			foundDirection := -1
 			curDirection := -1
 			adjacents := graph.Graph[string(prevChar)]
 			// Consider growing pattern by one character if j hasn't gone over the edge
-			if j < len(password) {
-				curChar := password[j]
+			if j < len(runes) {
+				curChar := runes[j]
 				for _, adj := range adjacents {
 					curDirection++
 
-					if idx := strings.Index(adj, string(curChar)); idx != -1 {
+					if level := keyLevel(adj, curChar); level != -1 {
 						found = true
 						foundDirection = curDirection
 
-						if idx == 1 {
-							// index 1 in the adjacency means the key is shifted, 0 means unshifted: A vs a, % vs 5, etc.
-							// for example, 'q' is adjacent to the entry '2@'. @ is shifted w/ index 1, 2 is unshifted.
-							shiftedCount++
+						// level 1 in the adjacency means the key is shifted, 0 means unshifted: A vs a, % vs 5, etc.
+						// for example, 'q' is adjacent to the entry '2@'. @ is shifted w/ level 1, 2 is unshifted.
+						// level 2 is typed with AltGr, like @ in the azerty entry 'à0@'.
+						if level < len(modified) {
+							modified[level]++
 						}
 
 						if lastDirection != foundDirection {
@@ -89,12 +140,13 @@ func spatialMatchHelper(password string, graph *adjacency.Graph) (matches []*mat
 					// don't consider length 1 or 2 chains.
 					matchSpc := &match.Match{
 						Pattern:      "spatial",
-						I:            i,
-						J:            j - 1,
-						Token:        password[i:j],
+						I:            offsets[i],
+						J:            offsets[j] - 1,
+						Token:        password[offsets[i]:offsets[j]],
 						Graph:        graph.Name,
 						Turns:        turns,
-						ShiftedCount: shiftedCount,
+						ShiftedCount: modified[levelShifted],
+						AltGrCount:   modified[levelAltGr],
 					}
 					matches = append(matches, matchSpc)
 				}
@@ -10,7 +10,7 @@ import (
 
 func Test_spatialMatch(t *testing.T) {
 	s := spatialMatch{
-		graphs: defaultGraphs,
+		graphs: loadDefaultAdjacencyGraphs(),
 	}
 	// doesn't match 1- and 2-character spatial patterns
 	assert.Empty(t, s.Matches(""))
@@ -20,7 +20,7 @@ func Test_spatialMatch(t *testing.T) {
 
 	// for testing, make a subgraph that contains a single keyboard
 	s = spatialMatch{
-		graphs: []*adjacency.Graph{adjacency.Graphs["qwerty"]},
+		graphs: []*adjacency.Graph{adjacency.Get("qwerty")},
 	}
 
 	pattern := "6tfGHJ"
@@ -56,11 +56,28 @@ func Test_spatialMatch(t *testing.T) {
 		{"*-632.0214", "mac_keypad", 9, 0},
 		{"aoEP%yIxkjq:", "dvorak", 4, 5},
 		{";qoaOQ:Aoq;a", "dvorak", 11, 4},
+		{"azertyuiop", "azerty", 1, 0},
+		{"12345", "azerty", 1, 5},
+		{"wxcvBN", "azerty", 1, 2},
+		{"qwertzuiop", "qwertz", 1, 0},
+		{"yxcvbnm", "qwertz", 1, 0},
+		{"arstdhneio", "colemak", 1, 0},
+		{"zxcvbkm", "colemak", 1, 0},
+		{"ashtgyneoi", "workman", 1, 0},
+		{"qdrwbj", "workman", 1, 0},
+		{"7890-=", "jcuken", 1, 0},
+		{"йцукен", "jcuken", 1, 0},
+		{"ЙЦУКЕН", "jcuken", 1, 6},
+		{"фывапролджэ", "jcuken", 1, 0},
+		{"ячсМИТЬ", "jcuken", 1, 4},
+		{"é\"'(-è", "azerty", 1, 0},
+		{"àç_è", "azerty", 1, 0},
+		{"üpoiu", "qwertz", 1, 0},
 	}
 	for _, tt := range tests {
 		t.Run(tt.pattern, func(t *testing.T) {
 			s := spatialMatch{
-				graphs: []*adjacency.Graph{adjacency.Graphs[tt.keyboard]},
+				graphs: []*adjacency.Graph{adjacency.Get(tt.keyboard)},
 			}
 			matches := s.Matches(tt.pattern)
 			assert.Equal(t,
@@ -80,3 +97,50 @@ func Test_spatialMatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_spatialMatchUnicode(t *testing.T) {
+	// offsets are in bytes, multi-byte characters are never split
+	s := spatialMatch{graphs: []*adjacency.Graph{adjacency.Get("jcuken")}}
+	assert.Equal(t, []*match.Match{
+		{
+			Pattern: "spatial",
+			Token:   "йцук",
+			I:       3,
+			J:       3 + len("йцук") - 1,
+			Graph:   "jcuken",
+			Turns:   1,
+		},
+	}, s.Matches("a\u00e9йцук!ё"))
+
+	// AltGr is a third key level, counted apart from shift
+	for _, tt := range []struct {
+		pattern  string
+		keyboard string
+		turns    int
+		shifts   int
+		altgr    int
+	}{
+		{"~#{[", "azerty", 1, 0, 4},
+		{"é#'5|", "azerty", 1, 1, 2},
+		{"a&~", "azerty", 2, 0, 1},
+		{"@w€r", "qwertz", 1, 0, 2},
+		{"@W€", "qwertz", 1, 1, 2},
+		{"²³$%", "qwertz", 1, 2, 2},
+	} {
+		t.Run(tt.pattern, func(t *testing.T) {
+			s := spatialMatch{graphs: []*adjacency.Graph{adjacency.Get(tt.keyboard)}}
+			assert.Equal(t, []*match.Match{
+				{
+					Pattern:      "spatial",
+					Token:        tt.pattern,
+					I:            0,
+					J:            len(tt.pattern) - 1,
+					Graph:        tt.keyboard,
+					Turns:        tt.turns,
+					ShiftedCount: tt.shifts,
+					AltGrCount:   tt.altgr,
+				},
+			}, s.Matches(tt.pattern))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package matching
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// trie is a prefix tree over the words of several ranked dictionaries.
+// It is built once and read-only afterwards: nodes, edges and entries are
+// stored in flat slices to keep it compact.
+type trie struct {
+	dictNames []string // dictionary names, sorted
+	nodes     []trieNode
+	edges     []trieEdge // children of each node, sorted by rune
+	entries   []indexEntry
+}
+
+type trieNode struct {
+	// edges[edgeStart:edgeEnd] are the children of the node
+	edgeStart, edgeEnd int32
+	// entries[entryStart:entryEnd] are the dictionaries holding the word ending at the node
+	entryStart, entryEnd int32
+}
+
+type trieEdge struct {
+	r    rune
+	node int32
+}
+
+func newTrie(dictionaries map[string]Dictionary) *trie {
+	t := &trie{}
+	for name := range dictionaries {
+		t.dictNames = append(t.dictNames, name)
+	}
+	sort.Strings(t.dictNames)
+
+	type word struct {
+		word string
+		indexEntry
+	}
+	var words []word
+	for i, name := range t.dictNames {
+		dictionaries[name].Each(func(w string, rank int) bool {
+			words = append(words, word{w, indexEntry{dict: int32(i), rank: int32(rank)}})
+			return true
+		})
+	}
+	// sorting the words groups them by prefix, in rune order.
+	sort.Slice(words, func(a, b int) bool {
+		if words[a].word != words[b].word {
+			return words[a].word < words[b].word
+		}
+		return words[a].dict < words[b].dict
+	})
+
+	// lay the nodes out breadth first, so that the children of a node are contiguous.
+	// the node at index k holds the words in words[queue[k].lo:queue[k].hi], all sharing
+	// their first queue[k].depth bytes.
+	type span struct{ lo, hi, depth int }
+	queue := []span{{0, len(words), 0}}
+	for k := 0; k < len(queue); k++ {
+		sp := queue[k]
+		node := trieNode{
+			edgeStart:  int32(len(t.edges)),
+			entryStart: int32(len(t.entries)),
+		}
+		lo := sp.lo
+		for ; lo < sp.hi && len(words[lo].word) == sp.depth; lo++ {
+			t.entries = append(t.entries, words[lo].indexEntry)
+		}
+		for lo < sp.hi {
+			r, size := utf8.DecodeRuneInString(words[lo].word[sp.depth:])
+			prefix := words[lo].word[:sp.depth+size]
+			hi := lo + 1
+			for hi < sp.hi && strings.HasPrefix(words[hi].word, prefix) {
+				hi++
+			}
+			t.edges = append(t.edges, trieEdge{r: r, node: int32(len(queue))})
+			queue = append(queue, span{lo, hi, sp.depth + size})
+			lo = hi
+		}
+		node.edgeEnd = int32(len(t.edges))
+		node.entryEnd = int32(len(t.entries))
+		t.nodes = append(t.nodes, node)
+	}
+	return t
+}
+
+// child returns the child of node n reached through r, or -1.
+func (t *trie) child(n int32, r rune) int32 {
+	edges := t.edges[t.nodes[n].edgeStart:t.nodes[n].edgeEnd]
+	k := sort.Search(len(edges), func(i int) bool { return edges[i].r >= r })
+	if k < len(edges) && edges[k].r == r {
+		return edges[k].node
+	}
+	return -1
+}
+
+func (t *trie) root() wordPos {
+	return wordPos{node: 0}
+}
+
+func (t *trie) next(p wordPos, r rune) (wordPos, bool) {
+	n := t.child(p.node, r)
+	return wordPos{node: n}, n >= 0
+}
+
+func (t *trie) wordEntries(p wordPos) []indexEntry {
+	node := t.nodes[p.node]
+	return t.entries[node.entryStart:node.entryEnd]
+}
+
+func (t *trie) names() []string {
+	return t.dictNames
+}
+
+func (t *trie) walk(s string, fn func(end int, word string, entries []indexEntry)) {
+	var word strings.Builder
+	n := int32(0)
+	for end := 0; end < len(s); {
+		r, size := utf8.DecodeRuneInString(s[end:])
+		end += size
+		lr := unicode.ToLower(r)
+		if n = t.child(n, lr); n < 0 {
+			return
+		}
+		word.WriteRune(lr)
+		if node := t.nodes[n]; node.entryEnd > node.entryStart {
+			fn(end, word.String(), t.entries[node.entryStart:node.entryEnd])
+		}
+	}
+}
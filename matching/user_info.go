@@ -0,0 +1,254 @@
+package matching
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UserInfo is the personal data of a user, whose parts are penalized when found in
+// their password. Empty fields are ignored.
+type UserInfo struct {
+	Name      string
+	Email     string
+	Username  string
+	Phone     string
+	BirthDate time.Time
+	Company   string
+	Address   string
+}
+
+// Inputs returns the user inputs the data of u is found as in passwords, to be matched
+// as the "user_inputs" dictionary along with the other user inputs:
+//   - the full values, lowercased,
+//   - the words of each value, and its letters and digits runs, like smith and 85 in
+//     Smith85, and the words joined together,
+//   - the initials of the name, alone and with the first or last name, like js, jsmith
+//     and johns, and the last name followed by the first one,
+//   - the local part of the email, without its +tag, and the labels of its domain but
+//     the top-level one,
+//   - the digits of the phone number, with its last 4, 7 and 10 digits,
+//   - the birth date in the formats recognized as dates: with 2- or 4-digit years, in
+//     any order, maybe zero-padded, separated or not, with English month names, and its
+//     day and month without the year when written with at least 4 digits.
+//
+// The most telling inputs come first, as user inputs are ranked by their order.
+// Inputs shorter than 2 characters are left out.
+func (u UserInfo) Inputs() []string {
+	var in userInputs
+	in.addName(u.Name)
+	in.addValue(u.Username)
+	in.addEmail(u.Email)
+	in.addValue(u.Company)
+	in.addPhone(u.Phone)
+	if !u.BirthDate.IsZero() {
+		in.add(fmt.Sprint(u.BirthDate.Year()))
+	}
+	in.addValue(u.Address)
+	in.addDate(u.BirthDate)
+	return in.inputs
+}
+
+// userInputs collects the distinct user inputs in their order.
+type userInputs struct {
+	inputs []string
+	seen   map[string]bool
+}
+
+func (in *userInputs) add(s string) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if utf8.RuneCountInString(s) < 2 || in.seen[s] {
+		return
+	}
+	if in.seen == nil {
+		in.seen = make(map[string]bool)
+	}
+	in.seen[s] = true
+	in.inputs = append(in.inputs, s)
+}
+
+// addValue adds s, its words, its runs of letters and digits and its words joined together.
+func (in *userInputs) addValue(s string) {
+	if s == "" {
+		return
+	}
+	in.add(s)
+	words := splitWords(s)
+	for _, w := range words {
+		in.add(w)
+	}
+	for _, w := range words {
+		for _, r := range letterDigitRuns(w) {
+			in.add(r)
+		}
+	}
+	in.add(strings.Join(words, ""))
+}
+
+func (in *userInputs) addName(name string) {
+	if name == "" {
+		return
+	}
+	in.addValue(name)
+	words := splitWords(name)
+	if len(words) < 2 {
+		return
+	}
+	first, last := words[0], words[len(words)-1]
+	var initials strings.Builder
+	for _, w := range words {
+		r, _ := utf8.DecodeRuneInString(w)
+		initials.WriteRune(r)
+	}
+	in.add(initials.String())
+	in.add(firstRune(first) + last)
+	in.add(first + firstRune(last))
+	in.add(last + first)
+}
+
+func (in *userInputs) addEmail(email string) {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		in.addValue(email)
+		return
+	}
+	local, domain := email[:at], email[at+1:]
+	in.add(email)
+	in.add(local)
+	if plus := strings.IndexByte(local, '+'); plus > 0 {
+		local = local[:plus]
+	}
+	in.addValue(local)
+	labels := strings.Split(domain, ".")
+	for _, label := range labels[:len(labels)-1] {
+		if !strings.EqualFold(label, "www") {
+			in.add(label)
+		}
+	}
+}
+
+func (in *userInputs) addPhone(phone string) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	if len(digits) < 4 {
+		return
+	}
+	in.add(digits)
+	for _, n := range []int{10, 7, 4} {
+		if len(digits) > n {
+			in.add(digits[len(digits)-n:])
+		}
+	}
+	for _, r := range letterDigitRuns(phone) {
+		if len(r) >= 3 {
+			in.add(r)
+		}
+	}
+}
+
+// dateInputSeparators are the separators the parts of a birth date are written with.
+var dateInputSeparators = []string{"", "/", "-", ".", " ", "_"}
+
+func (in *userInputs) addDate(date time.Time) {
+	if date.IsZero() {
+		return
+	}
+	day, month, year := date.Day(), int(date.Month()), date.Year()
+	days := paddings(day)
+	months := paddings(month)
+	years := []string{fmt.Sprint(year), fmt.Sprintf("%02d", year%100)}
+
+	// dates in digits have at least 4 of them, like 4385 or 04/03 but not 43
+	addDigits := func(sep string, parts ...string) {
+		n := 0
+		for _, p := range parts {
+			n += len(p)
+		}
+		if n >= 4 {
+			in.add(strings.Join(parts, sep))
+		}
+	}
+	for _, y := range years {
+		for _, sep := range dateInputSeparators {
+			for _, d := range days {
+				for _, m := range months {
+					addDigits(sep, d, m, y)
+					addDigits(sep, m, d, y)
+					addDigits(sep, y, m, d)
+					addDigits(sep, y, d, m)
+				}
+			}
+		}
+	}
+	for _, sep := range dateInputSeparators {
+		for _, d := range days {
+			for _, m := range months {
+				addDigits(sep, d, m)
+				addDigits(sep, m, d)
+			}
+		}
+	}
+
+	names := EnglishDateNames.Months[month-1]
+	for _, name := range names {
+		for _, sep := range []string{"", "-", " "} {
+			for _, d := range days {
+				for _, y := range years {
+					in.add(d + sep + name + sep + y)
+					in.add(name + sep + d + sep + y)
+					in.add(y + sep + name + sep + d)
+				}
+				in.add(d + sep + name)
+				in.add(name + sep + d)
+			}
+			in.add(name + sep + years[0])
+		}
+	}
+}
+
+// paddings returns n written in digits, and zero-padded to 2 digits if it has a single one.
+func paddings(n int) []string {
+	if n < 10 {
+		return []string{fmt.Sprint(n), fmt.Sprintf("%02d", n)}
+	}
+	return []string{fmt.Sprint(n)}
+}
+
+// splitWords returns the words of s, lowercased, separated by anything but letters and digits.
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsMark(r) && !unicode.IsDigit(r)
+	})
+}
+
+// letterDigitRuns returns the runs of letters and the runs of digits of s, like smith
+// and 85 in smith85.
+func letterDigitRuns(s string) []string {
+	var runs []string
+	start, digits := -1, false
+	for i, r := range s {
+		letter, digit := unicode.IsLetter(r) || unicode.IsMark(r), unicode.IsDigit(r)
+		if start >= 0 && ((!letter && !digit) || digit != digits) {
+			runs = append(runs, s[start:i])
+			start = -1
+		}
+		if start < 0 && (letter || digit) {
+			start, digits = i, digit
+		}
+	}
+	if start >= 0 {
+		runs = append(runs, s[start:])
+	}
+	return runs
+}
+
+func firstRune(s string) string {
+	_, size := utf8.DecodeRuneInString(s)
+	return s[:size]
+}
@@ -0,0 +1,69 @@
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserInfoInputs(t *testing.T) {
+	inputs := UserInfo{
+		Name:      "John Ronald Smith",
+		Email:     "J.Smith85+shop@mail.example.com",
+		Username:  "jsmith_85",
+		Phone:     "+1 (415) 555-0199",
+		BirthDate: time.Date(1985, time.March, 4, 0, 0, 0, 0, time.UTC),
+		Company:   "Acme Corp",
+		Address:   "1600 Main St, Springfield",
+	}.Inputs()
+
+	for _, want := range []string{
+		// name
+		"john ronald smith", "john", "ronald", "smith", "johnronaldsmith", "jrs", "jsmith", "johns", "smithjohn",
+		// username
+		"jsmith_85", "jsmith", "85",
+		// email
+		"j.smith85+shop@mail.example.com", "j.smith85", "smith85", "mail", "example",
+		// company
+		"acme corp", "acme", "corp", "acmecorp",
+		// phone
+		"14155550199", "4155550199", "5550199", "0199", "415", "555",
+		// address
+		"1600", "springfield",
+		// birth date
+		"1985", "04031985", "4/3/1985", "03-04-85", "1985.03.04", "85_4_3", "0403", "03/04",
+		"4march1985", "mar-04-85", "march1985", "4mar",
+	} {
+		assert.Contains(t, inputs, want)
+	}
+	for _, missing := range []string{"", "j", "1", "com", "shop", "01", "85/3/4/1985", "43", "4/3", "4-03"} {
+		assert.NotContains(t, inputs, missing)
+	}
+
+	// the most telling inputs come first and each input is listed once
+	assert.Equal(t, "john ronald smith", inputs[0])
+	seen := make(map[string]bool)
+	for _, in := range inputs {
+		assert.False(t, seen[in], in)
+		seen[in] = true
+	}
+
+	assert.Empty(t, UserInfo{}.Inputs())
+	assert.Equal(t, []string{"élodie"}, UserInfo{Name: "Élodie"}.Inputs())
+}
+
+func TestOmnimatcherUserInfo(t *testing.T) {
+	inputs := UserInfo{Email: "jane.doe@example.com", BirthDate: time.Date(1990, time.July, 14, 0, 0, 0, 0, time.UTC)}.Inputs()
+	matches := Default().Matches("JaneDoe14.07.90", inputs)
+	var tokens []string
+	for _, m := range matches {
+		if m.DictionaryName == "user_inputs" {
+			tokens = append(tokens, m.Token)
+		}
+	}
+	assert.Contains(t, tokens, "Jane")
+	assert.Contains(t, tokens, "Doe")
+	assert.Contains(t, tokens, "JaneDoe")
+	assert.Contains(t, tokens, "14.07.90")
+}
@@ -0,0 +1,260 @@
+package zxcvbn
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/akara-io/zxcvbn/adjacency"
+	"github.com/akara-io/zxcvbn/breach"
+	"github.com/akara-io/zxcvbn/frequency/packed"
+	"github.com/akara-io/zxcvbn/matching"
+)
+
+// Option configures an Estimator.
+type Option func(*config)
+
+type config struct {
+	matching   matching.Config
+	thresholds ScoreThresholds
+	profiles   []AttackProfile
+	maxLength  int
+	breach     breach.Checker
+}
+
+// WithDictionary adds a dictionary of words ranked by their order in words,
+// replacing any dictionary with the same name.
+func WithDictionary(name string, words []string) Option {
+	return func(c *config) {
+		c.matching.Dictionaries[name] = matching.BuildRankedDict(words)
+	}
+}
+
+// WithoutDictionary removes the dictionary with the given name.
+func WithoutDictionary(name string) Option {
+	return func(c *config) {
+		delete(c.matching.Dictionaries, name)
+	}
+}
+
+// WithDictionaries replaces all dictionaries, including the defaults.
+func WithDictionaries(lists map[string][]string) Option {
+	return func(c *config) {
+		c.matching.Dictionaries = make(map[string]matching.Dictionary, len(lists))
+		for name, words := range lists {
+			c.matching.Dictionaries[name] = matching.BuildRankedDict(words)
+		}
+	}
+}
+
+// WithPackedDictionaries adds the lists of dicts, like the lists of the languages of
+// frequency/lang, replacing any dictionary with the same name.
+// They are matched in place, so dicts must not be closed while the Estimator is in use.
+func WithPackedDictionaries(dicts ...*packed.Dict) Option {
+	return func(c *config) {
+		for _, d := range dicts {
+			for name, dict := range matching.PackedDictionaries(d) {
+				c.matching.Dictionaries[name] = dict
+			}
+		}
+	}
+}
+
+// WithKeyboardGraphs replaces the adjacency graphs used to find spatial patterns.
+func WithKeyboardGraphs(graphs ...*adjacency.Graph) Option {
+	return func(c *config) {
+		c.matching.Graphs = append([]*adjacency.Graph{}, graphs...)
+	}
+}
+
+// WithLayoutSwitches replaces the pairs of keyboard layouts whose words are looked for in
+// passwords typed with the wrong layout active. There are none by default.
+//
+// No Russian or Hebrew word list ships with the package: a switch only finds the words
+// of the intended layout held by a dictionary, added with WithDictionary or
+// WithPackedDictionaries, or by the user inputs. For пароль typed as gfhjkm:
+//
+//	zxcvbn.New(
+//		zxcvbn.WithDictionary("ru_words", russianWords),
+//		zxcvbn.WithLayoutSwitches(matching.LayoutSwitch{
+//			Typed:    adjacency.Get("qwerty"),
+//			Intended: adjacency.Get("jcuken"),
+//		}),
+//	)
+func WithLayoutSwitches(switches ...matching.LayoutSwitch) Option {
+	return func(c *config) {
+		c.matching.LayoutSwitches = append([]matching.LayoutSwitch{}, switches...)
+	}
+}
+
+// WithL33tTable replaces the table of l33t substitutions, which maps a letter
+// to the strings that may stand for it, like 4 or /-\ for a. matching.ExtendedL33tTable
+// extends the default table.
+func WithL33tTable(table map[string][]string) Option {
+	return func(c *config) {
+		c.matching.L33tTable = table
+	}
+}
+
+// WithRegex adds r to the regular expressions reported as regex matches, replacing any
+// regex with the same name. Its matches are estimated with r.Guesses and get its feedback.
+func WithRegex(r matching.NamedRegexp) Option {
+	return func(c *config) {
+		regexes := make([]matching.NamedRegexp, 0, len(c.matching.Regexes)+1)
+		for _, rx := range c.matching.Regexes {
+			if rx.Name != r.Name {
+				regexes = append(regexes, rx)
+			}
+		}
+		c.matching.Regexes = append(regexes, r)
+	}
+}
+
+// WithRegexes replaces the regular expressions reported as regex matches, in addition
+// to the recent years. There are none by default.
+func WithRegexes(regexes ...matching.NamedRegexp) Option {
+	return func(c *config) {
+		c.matching.Regexes = append([]matching.NamedRegexp{}, regexes...)
+	}
+}
+
+// WithDateNames replaces the languages of the month and weekday names recognized in
+// dates, English by default: include matching.EnglishDateNames to keep it.
+func WithDateNames(names ...matching.DateNames) Option {
+	return func(c *config) {
+		c.matching.DateNames = append([]matching.DateNames{}, names...)
+	}
+}
+
+// WithReferenceYear sets the year dates and recent years are compared to.
+// It defaults to the current year.
+func WithReferenceYear(year int) Option {
+	return func(c *config) {
+		c.matching.ReferenceYear = year
+	}
+}
+
+// WithRecentYearWindow sets the number of years before the reference year that are
+// matched as recent years, along with the scoring.MinYearSpace years after it. It
+// defaults to matching.DefaultRecentYearWindow; a negative window matches no year.
+func WithRecentYearWindow(years int) Option {
+	return func(c *config) {
+		c.matching.RecentYearWindow = years
+	}
+}
+
+// WithDateYearRange sets the four-digit years recognized in dates, 1000 to 2050 by default.
+// It panics if min is below 100, max is above 9999 or min is above max.
+func WithDateYearRange(min, max int) Option {
+	if min < 100 || min > max || max > 9999 {
+		panic(fmt.Sprintf("zxcvbn: invalid date year range %d-%d", min, max))
+	}
+	return func(c *config) {
+		c.matching.DateMinYear = min
+		c.matching.DateMaxYear = max
+	}
+}
+
+// WithTwoDigitYearPivot sets the last two-digit year read as 20xx in dates, the later
+// ones being read as 19xx. It defaults to 50. It panics if pivot is not between 1 and 99.
+func WithTwoDigitYearPivot(pivot int) Option {
+	if pivot < 1 || pivot > 99 {
+		panic(fmt.Sprintf("zxcvbn: invalid two-digit year pivot %d", pivot))
+	}
+	return func(c *config) {
+		c.matching.TwoDigitYearPivot = pivot
+	}
+}
+
+// WithStrictDates only recognizes the dates that exist, rejecting days like feb 30 or
+// feb 29 outside leap years, and weekdays that don't fall on the date they precede.
+func WithStrictDates() Option {
+	return func(c *config) {
+		c.matching.StrictDates = true
+	}
+}
+
+// WithoutPassphrases stops recognizing passphrases, words joined by a separator or in
+// camel case like correct-horse-battery-staple, which are then scored word by word.
+func WithoutPassphrases() Option {
+	return func(c *config) {
+		c.matching.DisablePassphrases = true
+	}
+}
+
+// WithNormalization sets the Unicode normalization form passwords are put in before being
+// matched against dictionaries, matching.NFC by default. matching.NFKC also finds words
+// typed with compatibility characters, like fullwidth letters.
+func WithNormalization(n matching.Normalization) Option {
+	return func(c *config) {
+		c.matching.Normalization = n
+	}
+}
+
+// WithDiacriticFolding finds dictionary words typed with more, fewer or other diacritics,
+// like cafe for café. Each letter typed with other diacritics adds guesses to the word.
+func WithDiacriticFolding() Option {
+	return func(c *config) {
+		c.matching.FoldDiacritics = true
+	}
+}
+
+// WithBreachFilter sets the filter of breached passwords looked up in passwords,
+// reported as breached matches. There is none by default.
+func WithBreachFilter(f *breach.Filter) Option {
+	return func(c *config) {
+		c.matching.BreachFilter = f
+	}
+}
+
+// WithBreachChecker sets the checker passwords are looked up with once evaluated,
+// such as a breach.Client querying a range API. There is none by default.
+func WithBreachChecker(c breach.Checker) Option {
+	return func(cfg *config) {
+		cfg.breach = c
+	}
+}
+
+// WithScoreThresholds sets the guesses thresholds used to compute the score.
+func WithScoreThresholds(t ScoreThresholds) Option {
+	return func(c *config) {
+		c.thresholds = t
+	}
+}
+
+// WithMaxLength sets the number of characters matched against patterns, the remaining
+// ones being scored as bruteforce. It bounds the evaluation cost of very long passwords.
+// Zero or less analyses the whole password. It defaults to DefaultMaxLength.
+func WithMaxLength(n int) Option {
+	return func(c *config) {
+		c.maxLength = n
+	}
+}
+
+// WithAttackProfiles adds attack profiles to the ones crack times are estimated for,
+// replacing any profile with the same name. It panics if a profile has no name or
+// a non-positive or infinite rate.
+func WithAttackProfiles(profiles ...AttackProfile) Option {
+	for _, p := range profiles {
+		if p.Name == "" || !(p.GuessesPerSecond > 0) || math.IsInf(p.GuessesPerSecond, 1) {
+			panic(fmt.Sprintf("zxcvbn: invalid attack profile %+v", p))
+		}
+	}
+	return func(c *config) {
+		merged := make([]AttackProfile, 0, len(c.profiles)+len(profiles))
+		for _, p := range c.profiles {
+			if !hasAttackProfile(profiles, p.Name) {
+				merged = append(merged, p)
+			}
+		}
+		c.profiles = append(merged, profiles...)
+	}
+}
+
+func hasAttackProfile(profiles []AttackProfile, name string) bool {
+	for _, p := range profiles {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package scoring
 import (
 	"math"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -20,7 +21,34 @@ const (
 	MinSubmatchGuessesMultiChar     = 50
 )
 
+// Scorer estimates the number of guesses needed to crack matches.
+// A Scorer is immutable and safe for concurrent use. Its zero value scores
+// relative to the package-level ReferenceYear.
+type Scorer struct {
+	// ReferenceYear is the year dates and recent years are compared to.
+	// Zero means ReferenceYear at the time of scoring.
+	ReferenceYear int
+	// Regexes estimate the guesses needed for the tokens matched by the regexes with the
+	// given names, taking precedence over the built-in estimates. It must not be modified
+	// once the Scorer is in use.
+	Regexes map[string]func(token string) float64
+}
+
+func (s Scorer) referenceYear() int {
+	if s.ReferenceYear != 0 {
+		return s.ReferenceYear
+	}
+	return ReferenceYear
+}
+
+// EstimateGuesses returns the guesses needed for m using the package-level ReferenceYear.
 func EstimateGuesses(m *match.Match, password string) float64 {
+	return Scorer{}.EstimateGuesses(m, password)
+}
+
+// EstimateGuesses returns the number of guesses needed to crack m, a match found in password.
+// The estimate is cached in m.Guesses.
+func (s Scorer) EstimateGuesses(m *match.Match, password string) float64 {
 	if m.Guesses > 0 {
 		// a match's guess estimate doesn't change. cache it.
 		return m.Guesses
@@ -46,9 +74,13 @@ func EstimateGuesses(m *match.Match, password string) float64 {
 	case "sequence":
 		guesses = SequenceGuesses(m)
 	case "regex":
-		guesses = RegexGuesses(m)
+		guesses = s.RegexGuesses(m)
 	case "date":
-		guesses = DateGuesses(m)
+		guesses = s.DateGuesses(m)
+	case "breached":
+		guesses = BreachedGuesses(m)
+	case "passphrase":
+		guesses = PassphraseGuesses(m)
 	default:
 		// panic("unknown pattern " + m.Pattern)
 	}
@@ -62,9 +94,9 @@ func EstimateGuesses(m *match.Match, password string) float64 {
 func BruteforceGuesses(m *match.Match) float64 {
 	runeCount := utf8.RuneCountInString(m.Token)
 	guesses := math.Pow(BruteforceCardinality, float64(runeCount))
-	/* if guesses == Number.POSITIVE_INFINITY {
-		guesses = math.MaxInt;
-	}*/
+	if math.IsInf(guesses, 1) {
+		guesses = math.MaxFloat64
+	}
 	// small detail: make bruteforce matches at minimum one guess bigger than smallest allowed
 	// submatch guesses, such that non-bruteforce submatches over the same [i..j] take precedence.
 	minGuesses := float64(0)
@@ -80,15 +112,27 @@ func BruteforceGuesses(m *match.Match) float64 {
 	return guesses
 }
 
+// BreachedGuesses returns the guesses of a breached password: an attacker trying the
+// breached passwords from the most common one needs at most its rank.
+func BreachedGuesses(m *match.Match) float64 {
+	return float64(m.Rank)
+}
+
 func DictionaryGuesses(m *match.Match) float64 {
 	m.BaseGuesses = float64(m.Rank)
 	m.UppercaseVariations = UppercaseVariations(m.Token)
 	m.L33tVariations = L33tVariations(m)
+	m.DiacriticVariations = DiacriticVariations(m)
 	reversedVariations := 1
 	if m.Reversed {
 		reversedVariations = 2
 	}
-	return float64(m.BaseGuesses) * float64(m.UppercaseVariations) * float64(m.L33tVariations) * float64(reversedVariations)
+	layoutVariations := 1
+	if m.Layout != "" {
+		layoutVariations = LayoutSwitchVariations
+	}
+	return float64(m.BaseGuesses) * float64(m.UppercaseVariations) * float64(m.L33tVariations) *
+		float64(m.DiacriticVariations) * float64(reversedVariations) * float64(layoutVariations)
 }
 
 var ReStartUpper = regexp.MustCompile(`^[A-Z][^A-Z]+$`)
@@ -135,18 +179,11 @@ func L33tVariations(m *match.Match) float64 {
 	}
 	// lower-case match.token before calculating: capitalization shouldn't affect l33t calc.
 	chrs := strings.ToLower(m.Token)
+	subbedCounts, chrCounts := countL33tSubs(chrs, m.Sub)
 	variations := float64(1)
 	for subbed, unsubbed := range m.Sub {
-		s := 0 // num of subbed chars
-		u := 0 // num of unsubbed chars
-		for _, c := range chrs {
-			if string(c) == subbed {
-				s++
-			}
-			if string(c) == unsubbed {
-				u++
-			}
-		}
+		s := subbedCounts[strings.ToLower(subbed)] // num of subbed chars
+		u := chrCounts[unsubbed]                   // num of unsubbed chars
 		if s == 0 || u == 0 {
 			// for this sub, password is either fully subbed (444) or fully unsubbed (aaa)
 			// treat that as doubling the space (attacker needs to try fully subbed chars in addition to
@@ -166,17 +203,69 @@ func L33tVariations(m *match.Match) float64 {
 	return variations
 }
 
+// DiacriticVariations returns the number of ways the attacker tries to add or remove the
+// diacritics of a dictionary word to find m, m.Diacritics of its letters being written
+// with other diacritics than in m.MatchedWord.
+// Like with capitalization, with café written cafe the attacker tries the word with one
+// letter changed, then with two, and so on. Changing every letter doubles the guesses.
+func DiacriticVariations(m *match.Match) float64 {
+	d := m.Diacritics
+	if d == 0 {
+		return 1
+	}
+	n := utf8.RuneCountInString(m.MatchedWord)
+	if d >= n {
+		return 2
+	}
+	variations := float64(0)
+	for i := 1; i <= d && i <= n-d; i++ {
+		variations += mathutils.NCk(n, i)
+	}
+	return variations
+}
+
+// countL33tSubs counts the substitutions of sub in the lowercase token, like the l33t
+// matcher finds them: the longest first, as substitutions may span several characters
+// like |< for k. The other characters are counted apart.
+func countL33tSubs(token string, sub map[string]string) (subbedCounts, chrCounts map[string]int) {
+	subbed := make([]string, 0, len(sub))
+	for s := range sub {
+		subbed = append(subbed, strings.ToLower(s))
+	}
+	sort.Slice(subbed, func(i, j int) bool { return len(subbed[i]) > len(subbed[j]) })
+
+	subbedCounts = make(map[string]int, len(subbed))
+	chrCounts = make(map[string]int)
+	for i := 0; i < len(token); {
+		found := ""
+		for _, s := range subbed {
+			if strings.HasPrefix(token[i:], s) {
+				found = s
+				break
+			}
+		}
+		if found != "" {
+			subbedCounts[found]++
+			i += len(found)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(token[i:])
+		chrCounts[token[i:i+size]]++
+		i += size
+	}
+	return subbedCounts, chrCounts
+}
+
+// SpatialGuesses returns the guesses needed for a spatial match, given the number of keys
+// and the average degree of its keyboard graph. Graphs unknown to the adjacency package
+// are estimated like the keypad.
 func SpatialGuesses(m *match.Match) float64 {
-	s := float64(0)
-	d := float64(0)
-	switch m.Graph {
-	case "qwerty", "dvorak":
-		s = float64(len(adjacency.Graphs["qwerty"].Graph))
-		d = adjacency.Graphs["qwerty"].AverageDegree
-	default:
-		s = float64(len(adjacency.Graphs["keypad"].Graph))
-		d = adjacency.Graphs["keypad"].AverageDegree
+	g := adjacency.Get(m.Graph)
+	if g == nil {
+		g = adjacency.Get("keypad")
 	}
+	s := float64(len(g.Graph))
+	d := g.AverageDegree
 	guesses := float64(0)
 	runeCount := utf8.RuneCountInString(m.Token)
 	l := runeCount
@@ -190,20 +279,52 @@ func SpatialGuesses(m *match.Match) float64 {
 	}
 	// add extra guesses for shifted keys. (% instead of 5, A instead of a.)
 	// math is similar to extra guesses of l33t substitutions in dictionary matches.
-	if m.ShiftedCount > 0 {
-		s := m.ShiftedCount
-		u := runeCount - m.ShiftedCount // unshifted count
-		if s == 0 || u == 0 {
-			guesses *= 2
-		} else {
-			shiftedVariations := float64(0)
-			for i := 1; i <= mathutils.Min(s, u); i++ {
-				shiftedVariations += mathutils.NCk(s+u, i)
-			}
-			guesses *= shiftedVariations
+	guesses *= modifiedKeyVariations(m.ShiftedCount, runeCount)
+	// keys typed with AltGr, a third level on many european layouts, are counted the same way.
+	guesses *= modifiedKeyVariations(m.AltGrCount, runeCount)
+	return (guesses)
+}
+
+// modifiedKeyVariations returns the guess multiplier of a spatial pattern of n keys
+// with k of them typed with a modifier.
+func modifiedKeyVariations(k, n int) float64 {
+	if k <= 0 {
+		return 1
+	}
+	u := n - k // unmodified count
+	if u <= 0 {
+		return 2
+	}
+	variations := float64(0)
+	for i := 1; i <= mathutils.Min(k, u); i++ {
+		variations += mathutils.NCk(k+u, i)
+	}
+	return variations
+}
+
+// LayoutSwitchVariations multiplies the guesses of a word typed with the wrong keyboard
+// layout active: like a reversed word, it is one more variation an attacker tries.
+const LayoutSwitchVariations = 2
+
+// PassphraseSeparators is the number of ways the words of a passphrase are joined: with a
+// space, a dash, a dot, an underscore or in camel case.
+const PassphraseSeparators = 5
+
+// PassphraseGuesses returns the guesses of a passphrase: an attacker combining words
+// tries every separator with every sequence of words, each word taking the guesses of
+// its rank and capitalization. The capitalization of camel case is given by the separator,
+// but for the first word which may or not be capitalized.
+// The guesses of each word are set in the Guesses of m.BaseMatches.
+func PassphraseGuesses(m *match.Match) float64 {
+	guesses := float64(PassphraseSeparators)
+	for k, w := range m.BaseMatches {
+		w.Guesses = float64(w.Rank)
+		if m.Separator != "" || k == 0 {
+			w.Guesses *= UppercaseVariations(w.Token)
 		}
+		guesses *= w.Guesses
 	}
-	return (guesses)
+	return guesses
 }
 
 func RepeatGuesses(m *match.Match) float64 {
@@ -211,14 +332,17 @@ func RepeatGuesses(m *match.Match) float64 {
 }
 
 func SequenceGuesses(m *match.Match) float64 {
-	firstChr := m.Token[0]
+	firstChr, _ := utf8.DecodeRuneInString(m.Token)
 	// lower guesses for obvious starting points
 	baseGuesses := 0
 	switch firstChr {
 	case 'a', 'A', 'z', 'Z', '0', '1', '9':
 		baseGuesses = 4
 	default:
-		if firstChr >= '0' && firstChr <= '9' {
+		if m.SequenceSpace > 0 {
+			// the size of the alphabet of the sequence, like 10 for digits or 24 for greek
+			baseGuesses = m.SequenceSpace
+		} else if firstChr >= '0' && firstChr <= '9' {
 			baseGuesses = 10 // digits
 		} else {
 			// could give a higher base for uppercase,
@@ -231,10 +355,22 @@ func SequenceGuesses(m *match.Match) float64 {
 		// 2x guesses
 		baseGuesses *= 2
 	}
-	return float64(baseGuesses * len(m.Token))
+	return float64(baseGuesses * utf8.RuneCountInString(m.Token))
 }
 
+// RegexGuesses returns the guesses needed for a regex match using the package-level ReferenceYear.
 func RegexGuesses(m *match.Match) float64 {
+	return Scorer{}.RegexGuesses(m)
+}
+
+// RegexGuesses returns the guesses needed for a regex match: the estimate of s.Regexes for
+// its regex if any, or the built-in one for the names alpha_lower, alpha_upper, alpha,
+// alphanumeric, digits, symbols and recent_year. Other regexes are estimated at 0, the
+// match then counting as the minimum number of guesses.
+func (s Scorer) RegexGuesses(m *match.Match) float64 {
+	if guesses, ok := s.Regexes[m.RegexName]; ok {
+		return guesses(m.Token)
+	}
 	switch m.RegexName {
 	case "alpha_lower":
 		return math.Pow(26, float64(len(m.Token)))
@@ -252,7 +388,7 @@ func RegexGuesses(m *match.Match) float64 {
 		// conservative estimate of year space: num years from REFERENCE_YEAR.
 		// if year is close to REFERENCE_YEAR, estimate a year space of MIN_YEAR_SPACE.
 		year, _ := strconv.Atoi(m.Token)
-		yearSpace := mathutils.Abs(year - ReferenceYear)
+		yearSpace := mathutils.Abs(year - s.referenceYear())
 		yearSpace = mathutils.Max(yearSpace, MinYearSpace)
 		return float64(yearSpace)
 	default:
@@ -262,15 +398,47 @@ func RegexGuesses(m *match.Match) float64 {
 
 const MinYearSpace = 20
 
+// ReferenceYear is the default year used by a zero Scorer.
 var ReferenceYear = time.Now().Year()
 
+// DateGuesses returns the guesses needed for a date match using the package-level ReferenceYear.
 func DateGuesses(m *match.Match) float64 {
+	return Scorer{}.DateGuesses(m)
+}
+
+func (s Scorer) DateGuesses(m *match.Match) float64 {
 	// base guesses: (year distance from ReferenceYear) * num_days * num_years
-	yearSpace := mathutils.Max(mathutils.Abs(m.Year-ReferenceYear), MinYearSpace)
-	guesses := yearSpace * 365
+	guesses := 1
+	if m.Year != 0 {
+		guesses = mathutils.Max(mathutils.Abs(m.Year-s.referenceYear()), MinYearSpace)
+	}
+	// dates written with names may lack the day, the month or the year: jan1990, Dec25, friday13
+	switch {
+	case m.Day != 0 && m.Month != 0:
+		guesses *= 365
+	case m.Month != 0:
+		guesses *= 12
+	case m.Day != 0:
+		guesses *= 31
+	}
 	// add factor of 4 for separator selection (one of ~4 choices)
 	if m.Separator != "" {
 		guesses *= 4
 	}
+	// month names are written full or abbreviated, days with or without an ordinal suffix
+	if m.MonthName != "" {
+		guesses *= 2
+	}
+	if m.Ordinal {
+		guesses *= 2
+	}
+	// the weekday of a full date follows from it, only its presence has to be guessed
+	if m.Weekday != "" {
+		if m.Year != 0 && m.Month != 0 && m.Day != 0 {
+			guesses *= 2
+		} else {
+			guesses *= 7
+		}
+	}
 	return float64(guesses)
 }
@@ -62,9 +62,33 @@ func TestSequenceGuesses(t *testing.T) {
 		// the repeat pattern '#{token}' has guesses of #{expected_guesses}
 		assert.Equal(t, tt.Guesses, guesses)
 	}
+
+	// the base is the size of the alphabet of the sequence, and the length is in runes
+	for _, tt := range []struct {
+		Token     string
+		Space     int
+		Ascending bool
+		Guesses   float64
+	}{
+		{"бвгд", 33, true, 33 * 4},
+		{"δγβ", 24, false, 24 * 3 * 2},
+		{"あいうえお", 46, true, 46 * 5},
+		{"３４５", 10, true, 10 * 3},
+		{"xyz", 26, true, 26 * 3},
+		{"abc", 26, true, 4 * 3},
+	} {
+		assert.Equal(t, tt.Guesses, scoring.SequenceGuesses(&match.Match{
+			Token:         tt.Token,
+			SequenceSpace: tt.Space,
+			Ascending:     tt.Ascending,
+		}), tt.Token)
+	}
 }
 
 func TestRegexGuesses(t *testing.T) {
+	// pin the reference year: 2005 must stay within MinYearSpace of it
+	s := scoring.Scorer{ReferenceYear: 2017}
+
 	// guesses of 26^7 for 7-char lowercase regex
 	assert.Equal(t, math.Pow(26, 7), scoring.RegexGuesses(&match.Match{
 		Token:     "aizocdk",
@@ -82,11 +106,33 @@ func TestRegexGuesses(t *testing.T) {
 		Token:     "1972",
 		RegexName: "recent_year",
 	}))
+	assert.EqualValues(t, 2017-1972, s.RegexGuesses(&match.Match{
+		Token:     "1972",
+		RegexName: "recent_year",
+	}))
 
-	assert.EqualValues(t, mathutils.Abs(scoring.MinYearSpace), scoring.RegexGuesses(&match.Match{
+	assert.EqualValues(t, mathutils.Abs(scoring.MinYearSpace), s.RegexGuesses(&match.Match{
 		Token:     "2005",
 		RegexName: "recent_year",
 	}))
+
+	// regexes with their own estimate, which takes precedence over the built-in one
+	s.Regexes = map[string]func(string) float64{
+		"employee_id": func(string) float64 { return 1e6 },
+		"digits":      func(token string) float64 { return float64(len(token)) },
+	}
+	assert.EqualValues(t, 1e6, s.RegexGuesses(&match.Match{
+		Token:     "AK-123456",
+		RegexName: "employee_id",
+	}))
+	assert.EqualValues(t, 4, s.RegexGuesses(&match.Match{
+		Token:     "1234",
+		RegexName: "digits",
+	}))
+	assert.EqualValues(t, 0, scoring.RegexGuesses(&match.Match{
+		Token:     "AK-123456",
+		RegexName: "employee_id",
+	}))
 }
 
 func TestDateGuesses(t *testing.T) {
@@ -109,10 +155,25 @@ func TestDateGuesses(t *testing.T) {
 	}
 	assert.EqualValues(t, 365*scoring.MinYearSpace*4, scoring.DateGuesses(m))
 
+	// named dates may lack the day, the month or the year, and their names and ordinals
+	// double the guesses
+	for _, tt := range []struct {
+		m    match.Match
+		want int
+	}{
+		{match.Match{Token: "jan2010", Year: 2010, Month: 1, MonthName: "jan"}, scoring.MinYearSpace * 12 * 2},
+		{match.Match{Token: "dec5th", Month: 12, Day: 5, MonthName: "dec", Ordinal: true}, 365 * 2 * 2},
+		{match.Match{Token: "friday13", Day: 13, Weekday: "friday"}, 31 * 7},
+		{match.Match{Token: "mon-1-jan-2010", Year: 2010, Month: 1, Day: 1, MonthName: "jan", Weekday: "mon", Separator: "-"},
+			scoring.MinYearSpace * 365 * 4 * 2 * 2},
+	} {
+		m := tt.m
+		assert.EqualValues(t, tt.want, scoring.DateGuesses(&m), tt.m.Token)
+	}
 }
 
 func TestSpatialGuesses(t *testing.T) {
-	keyboardStartingPositions := float64(len(adjacency.Graphs["qwerty"].Graph))
+	keyboardStartingPositions := float64(len(adjacency.Get("qwerty").Graph))
 
 	// with no turns or shifts, guesses is starts * degree * (len-1)
 	m := &match.Match{
@@ -122,7 +183,7 @@ func TestSpatialGuesses(t *testing.T) {
 		ShiftedCount: 0,
 	}
 	baseGuesses := keyboardStartingPositions *
-		adjacency.Graphs["qwerty"].AverageDegree *
+		adjacency.Get("qwerty").AverageDegree *
 		//     # - 1 term because: not counting spatial patterns of length 1
 		//     # eg for length==6, multiplier is 5 for needing to try len2,len3,..,len6
 		float64(len(m.Token)-1)
@@ -159,13 +220,38 @@ func TestSpatialGuesses(t *testing.T) {
 	guesses := float64(0)
 	l := len(m.Token)
 	s := keyboardStartingPositions
-	d := adjacency.Graphs["qwerty"].AverageDegree
+	d := adjacency.Get("qwerty").AverageDegree
 	for i := 2; i <= l; i++ {
 		for j := 1; j <= m.Turns && j <= i-1; j++ {
 			guesses += mathutils.NCk(i-1, j-1) * s * math.Pow(d, float64(j))
 		}
 	}
 	assert.Equal(t, guesses, scoring.SpatialGuesses(m))
+
+	// keys typed with AltGr add guesses like shifted ones
+	m = &match.Match{
+		Token:        "@W€rt",
+		Graph:        "qwertz",
+		Turns:        1,
+		ShiftedCount: 1,
+		AltGrCount:   2,
+	}
+	g := adjacency.Get("qwertz")
+	guesses = float64(len(g.Graph)) * g.AverageDegree * 4 *
+		mathutils.NCk(5, 1) *
+		(mathutils.NCk(5, 1) + mathutils.NCk(5, 2))
+	assert.Equal(t, guesses, scoring.SpatialGuesses(m))
+
+	// each keyboard has its own number of keys and average degree
+	for _, name := range []string{"azerty", "qwertz", "colemak", "workman", "jcuken", "mac_keypad"} {
+		g = adjacency.Get(name)
+		m = &match.Match{
+			Token: "abcdef",
+			Graph: name,
+			Turns: 1,
+		}
+		assert.Equal(t, float64(len(g.Graph))*g.AverageDegree*5, scoring.SpatialGuesses(m), name)
+	}
 }
 
 func TestDictionaryGuess(t *testing.T) {
@@ -205,6 +291,50 @@ func TestDictionaryGuess(t *testing.T) {
 		Sub:   map[string]string{"@": "a"},
 	}
 	assert.EqualValues(t, 32*scoring.L33tVariations(m)*scoring.UppercaseVariations(m.Token), scoring.DictionaryGuesses(m))
+
+	// words typed with the wrong keyboard layout are guessed with the layout switches
+	m = &match.Match{
+		Token:       "gfhjkm",
+		MatchedWord: "пароль",
+		Rank:        32,
+		Layout:      "jcuken",
+	}
+	assert.EqualValues(t, 32*scoring.LayoutSwitchVariations, scoring.DictionaryGuesses(m))
+}
+
+func TestBreachedGuesses(t *testing.T) {
+	// guesses == the rank, whatever the capitalization
+	assert.EqualValues(t, 1234, scoring.BreachedGuesses(&match.Match{
+		Pattern: "breached",
+		Token:   "PassWord",
+		Rank:    1234,
+	}))
+	assert.EqualValues(t, 1234, scoring.EstimateGuesses(&match.Match{
+		Pattern: "breached",
+		Token:   "PassWord",
+		Rank:    1234,
+	}, "PassWord"))
+}
+
+func TestPassphraseGuesses(t *testing.T) {
+	words := func(tokens ...string) []*match.Match {
+		var res []*match.Match
+		for k, token := range tokens {
+			res = append(res, &match.Match{Pattern: "dictionary", Token: token, Rank: 10 * (k + 1)})
+		}
+		return res
+	}
+	// guesses == separators * the product of the guesses of the words
+	m := &match.Match{Pattern: "passphrase", Token: "Correct-horse", Separator: "-", BaseMatches: words("Correct", "horse")}
+	assert.EqualValues(t, scoring.PassphraseSeparators*(10*2)*20, scoring.PassphraseGuesses(m))
+	assert.EqualValues(t, 20, m.BaseMatches[0].Guesses)
+	assert.EqualValues(t, 20, m.BaseMatches[1].Guesses)
+
+	// camel case capitalizes the words but the first
+	m = &match.Match{Pattern: "passphrase", Token: "CorrectHorse", BaseMatches: words("Correct", "Horse")}
+	assert.EqualValues(t, scoring.PassphraseSeparators*(10*2)*20, scoring.EstimateGuesses(m, "CorrectHorse"))
+	m = &match.Match{Pattern: "passphrase", Token: "correctHorse", BaseMatches: words("correct", "Horse")}
+	assert.EqualValues(t, scoring.PassphraseSeparators*10*20, scoring.EstimateGuesses(m, "correctHorse"))
 }
 
 func TestUppercaseVariants(t *testing.T) {
@@ -253,6 +383,12 @@ func TestL33tVariants(t *testing.T) {
 		{"a4a4aa", mathutils.NCk(6, 2) + mathutils.NCk(6, 1), map[string]string{"4": "a"}},
 		{"4a4a44", mathutils.NCk(6, 2) + mathutils.NCk(6, 1), map[string]string{"4": "a"}},
 		{"a44att+", (mathutils.NCk(4, 2) + mathutils.NCk(4, 1)) * mathutils.NCk(3, 1), map[string]string{"4": "a", "+": "t"}},
+		// substitutions of several characters count once, the longest first
+		{"|-|4(|<", 16, map[string]string{"|-|": "h", "4": "a", "(": "c", "|<": "k"}},
+		{"|<i|", 2 * mathutils.NCk(2, 1), map[string]string{"|<": "k", "|": "i"}},
+		{"vvow", mathutils.NCk(2, 1), map[string]string{"vv": "w"}},
+		{"PHone", 2, map[string]string{"ph": "f"}},
+		{"mоscow", mathutils.NCk(2, 1), map[string]string{"о": "o"}},
 	} {
 		m := &match.Match{Token: tt.Word, Sub: tt.Sub, L33t: len(tt.Sub) > 0}
 		assert.Equal(t, tt.Variants, scoring.L33tVariations(m))
@@ -263,3 +399,24 @@ func TestL33tVariants(t *testing.T) {
 	variants := mathutils.NCk(6, 2) + mathutils.NCk(6, 1)
 	assert.Equal(t, variants, scoring.L33tVariations(m))
 }
+
+func TestDiacriticVariations(t *testing.T) {
+	for _, tt := range []struct {
+		Word       string
+		Diacritics int
+		Variants   float64
+	}{
+		{"password", 0, 1},
+		{"café", 1, mathutils.NCk(4, 1)},
+		{"password", 2, mathutils.NCk(8, 1) + mathutils.NCk(8, 2)},
+		{"ñandú", 2, mathutils.NCk(5, 1) + mathutils.NCk(5, 2)},
+		{"éé", 2, 2},
+	} {
+		m := &match.Match{MatchedWord: tt.Word, Diacritics: tt.Diacritics}
+		assert.Equal(t, tt.Variants, scoring.DiacriticVariations(m), tt.Word)
+	}
+
+	// every diacritic to guess multiplies the guesses of the word
+	m := &match.Match{Token: "cafe", MatchedWord: "café", Rank: 10, Diacritics: 1}
+	assert.EqualValues(t, 10*4, scoring.DictionaryGuesses(m))
+}
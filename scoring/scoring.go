@@ -1,6 +1,7 @@
 package scoring
 
 import (
+	"context"
 	"math"
 	"sort"
 
@@ -44,7 +45,20 @@ type Result struct {
 //   - an attacker would also likely try length-1 (dictionary) and length-2 (dictionary-date)
 //     sequences before length-3. assuming at minimum D guesses per pattern type,
 //     D^(l-1) approximates Sum(D^i for i in [1..l-1]
-func MostGuessableMatchSequence(password string, matches []*match.Match, excludeAdditive bool) (result Result) {
+func MostGuessableMatchSequence(password string, matches []*match.Match, excludeAdditive bool) Result {
+	return Scorer{}.MostGuessableMatchSequence(password, matches, excludeAdditive)
+}
+
+// MostGuessableMatchSequence is like the package-level MostGuessableMatchSequence,
+// estimating match guesses with s.
+func (s Scorer) MostGuessableMatchSequence(password string, matches []*match.Match, excludeAdditive bool) Result {
+	result, _ := s.MostGuessableMatchSequenceContext(context.Background(), password, matches, excludeAdditive)
+	return result
+}
+
+// MostGuessableMatchSequenceContext is like MostGuessableMatchSequence but gives up
+// with ctx.Err() once ctx is done.
+func (s Scorer) MostGuessableMatchSequenceContext(ctx context.Context, password string, matches []*match.Match, excludeAdditive bool) (result Result, err error) {
 	n := len(password)
 	validIndexes := make([]bool, n)
 	for i := range password {
@@ -101,7 +115,7 @@ func MostGuessableMatchSequence(password string, matches []*match.Match, exclude
 	// than previously encountered sequences, updating state if so.
 	update := func(m *match.Match, l int) {
 		k := m.J
-		pi := EstimateGuesses(m, password)
+		pi := s.EstimateGuesses(m, password)
 		if l > 1 {
 			// we're considering a length-l sequence ending with match m:
 			// obtain the product term in the minimization function by multiplying m's guesses
@@ -199,6 +213,9 @@ func MostGuessableMatchSequence(password string, matches []*match.Match, exclude
 	}
 
 	for k := 0; k < n; k++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
 		for _, m := range matchesByJ[k] {
 			if m.I > 0 {
 				for l := 0; l < n; l++ {
@@ -231,6 +248,41 @@ func MostGuessableMatchSequence(password string, matches []*match.Match, exclude
 	return
 }
 
+// ExtendWithBruteforce extends result, the most guessable match sequence of a prefix of
+// password, to the whole of password by covering the remaining characters with bruteforce.
+func (s Scorer) ExtendWithBruteforce(result Result, password string, excludeAdditive bool) Result {
+	n := len(result.Password)
+	if n >= len(password) {
+		return result
+	}
+	sequence := append([]*match.Match(nil), result.Sequence...)
+	i := n
+	if l := len(sequence); l > 0 && sequence[l-1].Pattern == "bruteforce" {
+		// an optimal sequence never has two adjacent bruteforce matches: merge them.
+		i = sequence[l-1].I
+		sequence = sequence[:l-1]
+	}
+	sequence = append(sequence, makeBruteforceMatch(i, len(password)-1, password))
+
+	pi := float64(1)
+	for _, m := range sequence {
+		pi *= s.EstimateGuesses(m, password)
+	}
+	l := len(sequence)
+	guesses := mathutils.Factorial(l) * pi
+	if !excludeAdditive {
+		guesses += math.Pow(MinGuessesBeforeGrowingSequence, float64(l-1))
+	}
+	if math.IsInf(guesses, 1) {
+		guesses = math.MaxFloat64
+	}
+	return Result{
+		Password: password,
+		Guesses:  guesses,
+		Sequence: sequence,
+	}
+}
+
 // helper: make bruteforce match objects spanning i to j, inclusive.
 func makeBruteforceMatch(i int, j int, password string) *match.Match {
 	return &match.Match{
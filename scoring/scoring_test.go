@@ -1,6 +1,7 @@
 package scoring_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/akara-io/zxcvbn"
@@ -364,6 +365,7 @@ func TestMostGuessableMatchSequenceCoffeeScriptCompat(t *testing.T) {
 			BaseGuesses:         20,
 			UppercaseVariations: 2,
 			L33tVariations:      1,
+			DiacriticVariations: 1,
 			Guesses:             50},
 		{
 			Pattern:             "dictionary",
@@ -379,6 +381,7 @@ func TestMostGuessableMatchSequenceCoffeeScriptCompat(t *testing.T) {
 			BaseGuesses:         744,
 			UppercaseVariations: 2,
 			L33tVariations:      2,
+			DiacriticVariations: 1,
 			Guesses:             2976},
 		{
 			Pattern:             "dictionary",
@@ -394,6 +397,7 @@ func TestMostGuessableMatchSequenceCoffeeScriptCompat(t *testing.T) {
 			BaseGuesses:         712,
 			UppercaseVariations: 2,
 			L33tVariations:      4,
+			DiacriticVariations: 1,
 			Guesses:             5696},
 		{
 			Pattern:       "sequence",
@@ -440,3 +444,36 @@ func TestNonContinguousKeys(t *testing.T) {
 	//doesn't cause a crash
 	_ = zxcvbn.PasswordStrength("001��000", nil)
 }
+
+func TestExtendWithBruteforce(t *testing.T) {
+	const password = "0123456789"
+	s := scoring.Scorer{}
+
+	// a match followed by the bruteforced tail
+	prefix := s.MostGuessableMatchSequence(password[:6], []*match.Match{{I: 0, J: 5, Guesses: 1}}, true)
+	result := s.ExtendWithBruteforce(prefix, password, true)
+	assert.Equal(t, []*match.Match{
+		{I: 0, J: 5, Guesses: 1},
+		{Pattern: "bruteforce", I: 6, J: 9, Token: "6789", Guesses: 10000},
+	}, result.Sequence)
+	assert.Equal(t, password, result.Password)
+	assert.Equal(t, float64(2*1*10000), result.Guesses)
+
+	// a trailing bruteforce match is extended rather than followed by another one
+	prefix = s.MostGuessableMatchSequence(password[:6], []*match.Match{{I: 0, J: 2, Guesses: 1}}, true)
+	result = s.ExtendWithBruteforce(prefix, password, true)
+	assert.Equal(t, []*match.Match{
+		{I: 0, J: 2, Guesses: 1},
+		{Pattern: "bruteforce", I: 3, J: 9, Token: "3456789", Guesses: 1e7},
+	}, result.Sequence)
+
+	// nothing to extend
+	assert.Equal(t, result, s.ExtendWithBruteforce(result, password, true))
+}
+
+func TestMostGuessableMatchSequenceContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := scoring.Scorer{}.MostGuessableMatchSequenceContext(ctx, "0123456789", nil, false)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -5,51 +5,59 @@ import (
 	"math"
 )
 
+// EstimatedTimes holds the time needed to crack a password under several attack profiles,
+// keyed by profile name.
 type EstimatedTimes struct {
 	CrackTimesSeconds map[string]float64 `json:"crack_times_seconds"`
-	CrashTimesDisplay map[string]string  `json:"crack_times_display"`
-	Score             int
+	CrackTimesDisplay map[string]string  `json:"crack_times_display"`
 }
 
-func estimateAttackTimes(guesses float64) (t EstimatedTimes) {
+func estimateAttackTimes(guesses float64, profiles []AttackProfile) (t EstimatedTimes) {
 	// crack_times_seconds
-	t.CrackTimesSeconds = make(map[string]float64)
-	t.CrackTimesSeconds["online_throttling_100_per_hour"] = guesses / (100 / 3600)
-	t.CrackTimesSeconds["online_no_throttling_10_per_second"] = guesses / 10
-	t.CrackTimesSeconds["offline_slow_hashing_1e4_per_second"] = guesses / 1e4
-	t.CrackTimesSeconds["offline_fast_hashing_1e10_per_second"] = guesses / 1e10
+	t.CrackTimesSeconds = make(map[string]float64, len(profiles))
+	for _, p := range profiles {
+		seconds := guesses / p.GuessesPerSecond
+		if math.IsInf(seconds, 1) {
+			// keep the estimate representable in JSON
+			seconds = math.MaxFloat64
+		}
+		t.CrackTimesSeconds[p.Name] = seconds
+	}
 
-	t.CrashTimesDisplay = make(map[string]string)
+	t.CrackTimesDisplay = make(map[string]string, len(profiles))
 
 	for scenario, seconds := range t.CrackTimesSeconds {
-		t.CrashTimesDisplay[scenario] = displayTime(seconds)
+		t.CrackTimesDisplay[scenario] = displayTime(seconds)
 	}
-
-	t.Score = guessesToScore(guesses)
 	return
 }
 
-func guessesToScore(guesses float64) int {
+// ScoreThresholds holds the guesses below which a password scores 0, 1, 2 and 3.
+// Passwords needing at least ScoreThresholds[3] guesses score 4.
+type ScoreThresholds [4]float64
+
+// DefaultScoreThresholds are the thresholds used by upstream zxcvbn.
+var DefaultScoreThresholds = ScoreThresholds{
+	// risky password: "too guessable"
+	1e3,
+	// modest protection from throttled online attacks: "very guessable"
+	1e6,
+	// modest protection from unthrottled online attacks: "somewhat guessable"
+	1e8,
+	// modest protection from offline attacks: "safely unguessable"
+	// assuming a salted, slow hash function like bcrypt, scrypt, PBKDF2, argon, etc
+	1e10,
+	// strong protection from offline attacks under same scenario: "very unguessable"
+}
+
+func (t ScoreThresholds) score(guesses float64) int {
 	const DELTA = 5
-	if guesses < 1e3+DELTA {
-		// risky password: "too guessable"
-		return 0
-	}
-	if guesses < 1e6+DELTA {
-		// modest protection from throttled online attacks: "very guessable"
-		return 1
-	}
-	if guesses < 1e8+DELTA {
-		// modest protection from unthrottled online attacks: "somewhat guessable"
-		return 2
+	for score, threshold := range t {
+		if guesses < threshold+DELTA {
+			return score
+		}
 	}
-	if guesses < 1e10+DELTA {
-		// modest protection from offline attacks: "safely unguessable"
-		// assuming a salted, slow hash function like bcrypt, scrypt, PBKDF2, argon, etc
-		return 3
-	}
-	// strong protection from offline attacks under same scenario: "very unguessable"
-	return 4
+	return len(t)
 }
 
 func displayTime(seconds float64) string {
@@ -24,3 +24,21 @@ func Test_displayTime(t *testing.T) {
 		assert.Equal(t, tt.want, displayTime(tt.seconds))
 	}
 }
+
+func Test_estimateAttackTimes(t *testing.T) {
+	times := estimateAttackTimes(1e6, DefaultAttackProfiles)
+	for scenario, seconds := range map[string]float64{
+		"online_throttling_100_per_hour":       3.6e7,
+		"online_no_throttling_10_per_second":   1e5,
+		"offline_slow_hashing_1e4_per_second":  100,
+		"offline_fast_hashing_1e10_per_second": 1e-4,
+	} {
+		assert.InEpsilon(t, seconds, times.CrackTimesSeconds[scenario], 1e-12, scenario)
+	}
+	assert.Equal(t, map[string]string{
+		"online_throttling_100_per_hour":       "1 year",
+		"online_no_throttling_10_per_second":   "1 day",
+		"offline_slow_hashing_1e4_per_second":  "2 minutes",
+		"offline_fast_hashing_1e10_per_second": "less than a second",
+	}, times.CrackTimesDisplay)
+}
@@ -1,39 +1,186 @@
 package zxcvbn
 
 import (
+	"context"
+	"math"
 	"time"
 	"unicode/utf8"
 
+	"github.com/akara-io/zxcvbn/breach"
 	"github.com/akara-io/zxcvbn/feedback"
 	"github.com/akara-io/zxcvbn/match"
 	"github.com/akara-io/zxcvbn/matching"
-	"github.com/akara-io/zxcvbn/scoring"
 )
 
+// Result is the outcome of a password strength evaluation.
+// Its JSON encoding follows the output of upstream zxcvbn.
 type Result struct {
-	Guesses  float64
-	Sequence []*match.Match
-	Score    int
-	CalcTime float64
-	Feedback feedback.Feedback
+	Guesses      float64           `json:"guesses"`
+	GuessesLog10 float64           `json:"guesses_log10"`
+	Sequence     []*match.Match    `json:"sequence"`
+	Score        int               `json:"score"`
+	CalcTime     float64           `json:"calc_time"`
+	Feedback     feedback.Feedback `json:"feedback"`
+	// Breaches is the number of times the password was seen in data breaches according
+	// to the breach checker of the Estimator, if any. A breached password only has its
+	// Score and Feedback overridden: Guesses, GuessesLog10 and the EstimatedTimes are
+	// still those of its patterns.
+	Breaches int `json:"breaches,omitempty"`
+	EstimatedTimes
 }
 
+// Estimator evaluates password strength using its own dictionaries, keyboard graphs,
+// l33t table, regexes, reference year and score thresholds.
+// An Estimator is immutable and safe for concurrent use.
+type Estimator struct {
+	// matcher is nil for the default Estimator, which follows matching.Default.
+	matcher    *matching.Omnimatcher
+	advisor    feedback.Advisor
+	thresholds ScoreThresholds
+	profiles   []AttackProfile
+	maxLength  int
+	breach     breach.Checker
+}
+
+// DefaultMaxLength is the default number of characters analysed by an Estimator.
+const DefaultMaxLength = 256
+
+// New returns an Estimator using the package defaults modified by opts.
+func New(opts ...Option) *Estimator {
+	c := config{
+		matching:   matching.DefaultConfig(),
+		thresholds: DefaultScoreThresholds,
+		profiles:   DefaultAttackProfiles,
+		maxLength:  DefaultMaxLength,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	matcher := matching.NewOmnimatcher(c.matching)
+	return &Estimator{
+		matcher:    matcher,
+		advisor:    newAdvisor(matcher.Regexes()),
+		thresholds: c.thresholds,
+		profiles:   c.profiles,
+		maxLength:  c.maxLength,
+		breach:     c.breach,
+	}
+}
+
+var defaultEstimator = &Estimator{
+	thresholds: DefaultScoreThresholds,
+	profiles:   DefaultAttackProfiles,
+	maxLength:  DefaultMaxLength,
+}
+
+// Preload loads the default dictionaries and keyboard graphs, which otherwise happens
+// on the first evaluation. Servers may call it at startup to keep that cost off the
+// first request.
+func Preload() {
+	matching.Preload()
+}
+
+// PasswordStrength evaluates password with the default Estimator.
 func PasswordStrength(password string, userInputs []string) Result {
+	return defaultEstimator.PasswordStrength(password, userInputs)
+}
+
+// PasswordStrengthContext evaluates password with the default Estimator,
+// giving up with ctx.Err() once ctx is done.
+func PasswordStrengthContext(ctx context.Context, password string, userInputs []string) (Result, error) {
+	return defaultEstimator.PasswordStrengthContext(ctx, password, userInputs)
+}
+
+// PasswordStrength evaluates password, penalizing words found in userInputs.
+//
+// If the breach checker of e fails, the password is scored as if it had not been
+// breached; use PasswordStrengthContext to get the error.
+func (e *Estimator) PasswordStrength(password string, userInputs []string) Result {
+	result, _ := e.PasswordStrengthContext(context.Background(), password, userInputs)
+	return result
+}
+
+// PasswordStrengthContext is like PasswordStrength but gives up with ctx.Err() once ctx is done.
+//
+// A password reported as breached by the breach checker of e has a score of 0 and the
+// breached feedback, its guesses and estimated times being left alone. If the check
+// fails, the result is returned without it, along with the error.
+//
+// Only the first characters of password, up to the maximum length of e, are matched
+// against patterns: the remaining ones are scored as bruteforce.
+func (e *Estimator) PasswordStrengthContext(ctx context.Context, password string, userInputs []string) (Result, error) {
 	start := time.Now()
 	var result Result
 	if !utf8.ValidString(password) {
 		// Do not evaluate passwords containing invalid utf8
 		// => those will be reported as weak passwords
-		return result
+		return result, nil
+	}
+	analysed := truncate(password, e.maxLength)
+	matcher, advisor := e.matcher, e.advisor
+	if matcher == nil {
+		matcher = matching.Default()
+		advisor = newAdvisor(matcher.Regexes())
 	}
-	matches := matching.Omnimatch(password, userInputs)
-	seq := scoring.MostGuessableMatchSequence(password, matches, false)
-	end := time.Now()
-	calcTime := end.Nanosecond() - start.Nanosecond()
-	result.CalcTime = round(float64(calcTime)*time.Nanosecond.Seconds(), .5, 3)
+	scorer := matcher.Scorer()
+	matches, err := matcher.MatchesContext(ctx, analysed, userInputs)
+	if err != nil {
+		return result, err
+	}
+	seq, err := scorer.MostGuessableMatchSequenceContext(ctx, analysed, matches, false)
+	if err != nil {
+		return result, err
+	}
+	seq = scorer.ExtendWithBruteforce(seq, password, false)
+	result.CalcTime = round(time.Since(start).Seconds(), .5, 3)
 	result.Sequence = seq.Sequence
 	result.Guesses = seq.Guesses
-	result.Score = guessesToScore(seq.Guesses)
-	result.Feedback = feedback.GetFeedback(result.Score, result.Sequence)
-	return result
+	result.GuessesLog10 = math.Log10(seq.Guesses)
+	result.EstimatedTimes = estimateAttackTimes(seq.Guesses, e.profiles)
+	result.Score = e.thresholds.score(seq.Guesses)
+	result.Feedback = advisor.GetFeedback(result.Score, result.Sequence)
+	if e.breach != nil {
+		count, err := e.breach.Check(ctx, password)
+		if err != nil {
+			return result, err
+		}
+		if count > 0 {
+			result.Breaches = count
+			result.Score = 0
+			result.Feedback = feedback.GetBreachedFeedback()
+		}
+	}
+	return result, nil
+}
+
+// newAdvisor returns an Advisor giving the feedback of the regexes that have one.
+func newAdvisor(regexes []matching.NamedRegexp) feedback.Advisor {
+	var advisor feedback.Advisor
+	for _, r := range regexes {
+		if r.Warning == "" && len(r.Suggestions) == 0 {
+			continue
+		}
+		if advisor.Regexes == nil {
+			advisor.Regexes = make(map[string]feedback.Feedback)
+		}
+		advisor.Regexes[r.Name] = feedback.Feedback{
+			Warning:     r.Warning,
+			Suggestions: append([]string{}, r.Suggestions...),
+		}
+	}
+	return advisor
+}
+
+// truncate returns the first n runes of s, or s if n is not positive.
+func truncate(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
 }
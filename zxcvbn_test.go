@@ -1,14 +1,32 @@
 package zxcvbn
 
 import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/akara-io/zxcvbn/adjacency"
+	"github.com/akara-io/zxcvbn/breach"
+	"github.com/akara-io/zxcvbn/feedback"
+	"github.com/akara-io/zxcvbn/frequency"
+	"github.com/akara-io/zxcvbn/frequency/lang"
+	"github.com/akara-io/zxcvbn/frequency/lang/de"
+	"github.com/akara-io/zxcvbn/frequency/lang/fr"
+	"github.com/akara-io/zxcvbn/frequency/packed"
 	"github.com/akara-io/zxcvbn/match"
+	"github.com/akara-io/zxcvbn/matching"
 	"github.com/akara-io/zxcvbn/scoring"
 
 	"github.com/stretchr/testify/assert"
@@ -39,6 +57,8 @@ func TestPasswordStrength(t *testing.T) {
 	scoring.ReferenceYear = testdata.TimeStamp.Year()
 	// maximum epsilon for guesses comparison
 	const maxEpsilonGuesses = 1e-15
+	// upstream zxcvbn has no passphrase pattern, scoring passphrases word by word
+	estimator := New(WithoutPassphrases())
 	for _, td := range testdata.Tests {
 		t.Run(td.Password, func(t *testing.T) {
 			// map character positions to rune position
@@ -49,7 +69,7 @@ func TestPasswordStrength(t *testing.T) {
 				c++
 			}
 			runeMap[len(td.Password)] = c
-			s := PasswordStrength(td.Password, nil)
+			s := estimator.PasswordStrength(td.Password, nil)
 			if len(s.Sequence) == len(td.Sequence) {
 				for j := range td.Sequence {
 					expect, _ := json.Marshal(td.Sequence[j])
@@ -110,3 +130,416 @@ func TestFeedbackIsReturned(t *testing.T) {
 	assert.Equal(t, "Add another word or two. Uncommon words are better.", result.Feedback.Suggestions[0])
 	assert.Equal(t, "Predictable substitutions like '@' instead of 'a' don't help very much", result.Feedback.Suggestions[1])
 }
+
+func TestEstimatorsAreIndependent(t *testing.T) {
+	tenantA := New(WithDictionary("tenant", []string{"akaraio"}))
+	tenantB := New(WithoutDictionary("passwords"), WithReferenceYear(1990))
+
+	a := tenantA.PasswordStrength("akaraio", nil)
+	require.Len(t, a.Sequence, 1)
+	assert.Equal(t, "tenant", a.Sequence[0].DictionaryName)
+	assert.Equal(t, 0, a.Score)
+
+	b := tenantB.PasswordStrength("akaraio", nil)
+	for _, m := range b.Sequence {
+		assert.NotEqual(t, "tenant", m.DictionaryName)
+	}
+	assert.NotEqual(t, "passwords", tenantB.PasswordStrength("password", nil).Sequence[0].DictionaryName)
+
+	// the package-level estimator is unaffected
+	assert.Equal(t, "passwords", PasswordStrength("password", nil).Sequence[0].DictionaryName)
+
+	// dates are scored relative to the estimator's reference year
+	assert.Less(t,
+		tenantB.PasswordStrength("1989", nil).Guesses,
+		New(WithReferenceYear(2090)).PasswordStrength("1989", nil).Guesses)
+}
+
+func TestEstimatorScoreThresholds(t *testing.T) {
+	e := New(WithScoreThresholds(ScoreThresholds{1e20, 1e30, 1e40, 1e50}))
+	assert.Equal(t, 0, e.PasswordStrength("correcthorsebatterystaple", nil).Score)
+	assert.Equal(t, 4, PasswordStrength("correcthorsebatterystaple", nil).Score)
+}
+
+func TestEstimatorConcurrentUse(t *testing.T) {
+	e := New(WithDictionary("tenant", []string{"akaraio"}))
+	want := e.PasswordStrength("akaraio1990!", []string{"john"})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got := e.PasswordStrength("akaraio1990!", []string{"john"})
+			assert.Equal(t, want.Guesses, got.Guesses)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestResultCrackTimes(t *testing.T) {
+	result := PasswordStrength("correcthorsebatterystaple", nil)
+	assert.InDelta(t, math.Log10(result.Guesses), result.GuessesLog10, 1e-12)
+	assert.Equal(t, result.Guesses/1e10, result.CrackTimesSeconds["offline_fast_hashing_1e10_per_second"])
+	assert.Equal(t, "centuries", result.CrackTimesDisplay["online_throttling_100_per_hour"])
+
+	b, err := json.Marshal(result)
+	require.NoError(t, err)
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &fields))
+	for _, key := range []string{"guesses", "guesses_log10", "sequence", "score", "calc_time", "feedback", "crack_times_seconds", "crack_times_display"} {
+		assert.Contains(t, fields, key)
+	}
+}
+
+func TestPasswordStrengthContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := PasswordStrengthContext(ctx, "correcthorsebatterystaple", nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	result, err := PasswordStrengthContext(context.Background(), "correcthorsebatterystaple", nil)
+	require.NoError(t, err)
+	assert.Equal(t, PasswordStrength("correcthorsebatterystaple", nil).Guesses, result.Guesses)
+
+	// the deadline is honoured even without a length cap
+	password := strings.Repeat("1|7!@4$5a3%2({[<+890", 100)
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err = New(WithMaxLength(0)).PasswordStrengthContext(ctx, password, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestMaxLength(t *testing.T) {
+	password := strings.Repeat("p4ssw0rd!", 100*1024/9)
+	start := time.Now()
+	result := PasswordStrength(password, nil)
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	last := result.Sequence[len(result.Sequence)-1]
+	assert.Equal(t, "bruteforce", last.Pattern)
+	assert.Equal(t, len(password)-1, last.J)
+	assert.Equal(t, 4, result.Score)
+	_, err := json.Marshal(result)
+	assert.NoError(t, err)
+
+	// the tail is scored as bruteforce
+	e := New(WithMaxLength(8))
+	result = e.PasswordStrength("passwordpassword", nil)
+	require.Len(t, result.Sequence, 2)
+	assert.Equal(t, "dictionary", result.Sequence[0].Pattern)
+	assert.Equal(t, "bruteforce", result.Sequence[1].Pattern)
+	assert.Equal(t, "password", result.Sequence[1].Token)
+	assert.Equal(t, "repeat", PasswordStrength("passwordpassword", nil).Sequence[0].Pattern)
+}
+
+func TestRegisteredDictionary(t *testing.T) {
+	defaults := matching.Default()
+	t.Cleanup(func() { matching.SetDefault(defaults) })
+	words, err := frequency.ReadList(strings.NewReader("akaraworks\nzxcvbnport\n"), frequency.Text)
+	require.NoError(t, err)
+	require.NoError(t, matching.RegisterDictionary("company", words))
+
+	result := PasswordStrength("zxcvbnport", nil)
+	require.Len(t, result.Sequence, 1)
+	assert.Equal(t, "company", result.Sequence[0].DictionaryName)
+	assert.Equal(t, 2, result.Sequence[0].Rank)
+
+	// estimators created afterwards include it too
+	assert.Equal(t, "company", New().PasswordStrength("akaraworks", nil).Sequence[0].DictionaryName)
+}
+
+func TestPackedDictionaries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "company.zxd")
+	require.NoError(t, ioutil.WriteFile(path, packed.Encode(map[string][]string{
+		"company":   {"akaraworks", "zxcvbnport"},
+		"passwords": {"akarapass"},
+	}), 0o644))
+	d, err := packed.Open(path)
+	require.NoError(t, err)
+	defer d.Close()
+
+	e := New(WithPackedDictionaries(d))
+	result := e.PasswordStrength("zxcvbnport", nil)
+	require.Len(t, result.Sequence, 1)
+	assert.Equal(t, "company", result.Sequence[0].DictionaryName)
+	assert.Equal(t, 2, result.Sequence[0].Rank)
+
+	// lists replace the dictionaries with the same name
+	assert.Equal(t, "passwords", e.PasswordStrength("akarapass", nil).Sequence[0].DictionaryName)
+	assert.NotEqual(t, "passwords", e.PasswordStrength("password", nil).Sequence[0].DictionaryName)
+}
+
+func TestLanguages(t *testing.T) {
+	e := New(WithPackedDictionaries(fr.Dict(), de.Dict()))
+	for _, tt := range []struct{ password, list string }{
+		{"bonjour", "fr_words"},
+		{"dupont", "fr_surnames"},
+		{"müller", "de_surnames"},
+	} {
+		result := e.PasswordStrength(tt.password, nil)
+		require.Len(t, result.Sequence, 1, tt.password)
+		assert.Equal(t, tt.list, result.Sequence[0].DictionaryName, tt.password)
+		assert.Less(t, result.Guesses, PasswordStrength(tt.password, nil).Guesses, tt.password)
+	}
+	assert.Equal(t, "Names and surnames by themselves are easy to guess",
+		e.PasswordStrength("dupont", nil).Feedback.Warning)
+
+	// languages are picked by code
+	d, ok := lang.Dict("zh")
+	require.True(t, ok)
+	result := New(WithPackedDictionaries(d)).PasswordStrength("woaini", nil)
+	assert.Equal(t, "zh_words", result.Sequence[0].DictionaryName)
+	assert.Equal(t, "A word by itself is easy to guess", result.Feedback.Warning)
+}
+
+func TestDateNames(t *testing.T) {
+	result := PasswordStrength("15march1987", nil)
+	require.Len(t, result.Sequence, 1)
+	assert.Equal(t, "date", result.Sequence[0].Pattern)
+	assert.Equal(t, "march", result.Sequence[0].MonthName)
+
+	result = New(WithDateNames(matching.FrenchDateNames)).PasswordStrength("14juillet1789", nil)
+	require.Len(t, result.Sequence, 1)
+	assert.Equal(t, "date", result.Sequence[0].Pattern)
+	assert.Equal(t, 7, result.Sequence[0].Month)
+}
+
+func TestDateOptions(t *testing.T) {
+	date := func(e *Estimator, password string) *match.Match {
+		result := e.PasswordStrength(password, nil)
+		if len(result.Sequence) == 1 && result.Sequence[0].Pattern == "date" {
+			return result.Sequence[0]
+		}
+		return nil
+	}
+
+	assert.NotNil(t, date(New(), "31/02/1990"))
+	assert.Nil(t, date(New(WithStrictDates()), "31/02/1990"))
+	assert.NotNil(t, date(New(WithStrictDates()), "29/02/1996"))
+
+	assert.Nil(t, date(New(), "1/1/2075"))
+	if m := date(New(WithDateYearRange(1900, 2100)), "1/1/2075"); assert.NotNil(t, m) {
+		assert.Equal(t, 2075, m.Year)
+	}
+
+	// with reference years close to either reading, so that the date is the best match
+	if m := date(New(WithReferenceYear(1970)), "1/1/60"); assert.NotNil(t, m) {
+		assert.Equal(t, 1960, m.Year)
+	}
+	if m := date(New(WithReferenceYear(2050), WithTwoDigitYearPivot(70)), "1/1/60"); assert.NotNil(t, m) {
+		assert.Equal(t, 2060, m.Year)
+	}
+
+	// invalid ranges and pivots are rejected when the option is built
+	for _, r := range [][2]int{{2050, 1000}, {99, 2050}, {-1, 2050}, {1000, 10000}, {0, 0}} {
+		assert.Panics(t, func() { WithDateYearRange(r[0], r[1]) }, "range %d-%d", r[0], r[1])
+	}
+	assert.NotPanics(t, func() { WithDateYearRange(100, 9999) })
+	assert.NotPanics(t, func() { WithDateYearRange(2000, 2000) })
+	for _, pivot := range []int{-1, 0, 100} {
+		assert.Panics(t, func() { WithTwoDigitYearPivot(pivot) }, "pivot %d", pivot)
+	}
+	assert.NotPanics(t, func() { WithTwoDigitYearPivot(1) })
+	assert.NotPanics(t, func() { WithTwoDigitYearPivot(99) })
+}
+
+func TestRecentYears(t *testing.T) {
+	recentYear := func(e *Estimator, password string) bool {
+		result := e.PasswordStrength(password, nil)
+		return len(result.Sequence) == 1 && result.Sequence[0].RegexName == "recent_year"
+	}
+	assert.True(t, recentYear(New(WithReferenceYear(2026)), "2024"))
+	assert.True(t, recentYear(New(WithReferenceYear(2026)), "2044"))
+	assert.False(t, recentYear(New(WithReferenceYear(2026)), "2099"))
+	assert.True(t, recentYear(New(WithReferenceYear(2080)), "2099"))
+	assert.False(t, recentYear(New(WithReferenceYear(2026), WithRecentYearWindow(20)), "1980"))
+	assert.False(t, recentYear(New(WithRecentYearWindow(-1)), "2024"))
+}
+
+func TestCustomRegex(t *testing.T) {
+	employeeID := matching.NamedRegexp{
+		Name:        "employee_id",
+		Regexp:      regexp.MustCompile(`AK-\d{6}`),
+		Guesses:     func(string) float64 { return 1e6 },
+		Warning:     "Employee IDs are easy to guess",
+		Suggestions: []string{"Avoid your employee ID"},
+	}
+	e := New(WithRegex(employeeID))
+	result := e.PasswordStrength("AK-481516", nil)
+	require.Len(t, result.Sequence, 1)
+	assert.Equal(t, "employee_id", result.Sequence[0].RegexName)
+	assert.Equal(t, 1e6, result.Sequence[0].Guesses)
+	assert.Equal(t, "Employee IDs are easy to guess", result.Feedback.Warning)
+	assert.Contains(t, result.Feedback.Suggestions, "Avoid your employee ID")
+
+	// it is weaker than without the regex
+	assert.Greater(t, PasswordStrength("AK-481516", nil).Guesses, result.Guesses)
+
+	// WithRegex replaces the regex with the same name
+	employeeID.Guesses = func(string) float64 { return 1e3 }
+	e = New(WithRegex(employeeID), WithRegex(employeeID))
+	assert.Equal(t, 1e3, e.PasswordStrength("AK-481516", nil).Sequence[0].Guesses)
+}
+
+func TestExtendedL33tTable(t *testing.T) {
+	e := New(WithL33tTable(matching.ExtendedL33tTable))
+	for _, tt := range []struct{ password, word string }{
+		{"|-|4(|<3r", "hacker"},
+		{"vvizard", "wizard"},
+		{"рassword", "password"}, // a cyrillic р
+	} {
+		result := e.PasswordStrength(tt.password, nil)
+		if assert.Len(t, result.Sequence, 1, tt.password) {
+			m := result.Sequence[0]
+			assert.True(t, m.L33t, tt.password)
+			assert.Equal(t, tt.word, m.MatchedWord)
+			assert.Equal(t, tt.password, m.Token)
+		}
+		assert.Contains(t, result.Feedback.Suggestions,
+			"Predictable substitutions like '@' instead of 'a' don't help very much", tt.password)
+		assert.Less(t, result.Guesses, PasswordStrength(tt.password, nil).Guesses, tt.password)
+	}
+}
+
+func TestPassphrases(t *testing.T) {
+	e := New(WithoutPassphrases())
+	for _, password := range []string{
+		"correct-horse-battery-staple",
+		"correct.horse.battery.staple",
+		"correct horse battery staple",
+		"correct_horse_battery_staple",
+		"CorrectHorseBatteryStaple",
+	} {
+		result := PasswordStrength(password, nil)
+		if assert.Len(t, result.Sequence, 1, password) {
+			m := result.Sequence[0]
+			assert.Equal(t, "passphrase", m.Pattern, password)
+			assert.Equal(t, 4, m.WordCount, password)
+		}
+		// a single pattern instead of words and bruteforced separators
+		assert.Less(t, result.Guesses, e.PasswordStrength(password, nil).Guesses, password)
+	}
+
+	result := PasswordStrength("hello world", nil)
+	assert.Equal(t, "Passphrases of 2 common words are easy to guess", result.Feedback.Warning)
+	assert.NotEqual(t, "passphrase", e.PasswordStrength("hello world", nil).Sequence[0].Pattern)
+}
+
+func TestDiacriticFolding(t *testing.T) {
+	e := New(WithDiacriticFolding())
+	result := e.PasswordStrength("pässwörd", nil)
+	if assert.Len(t, result.Sequence, 1) {
+		m := result.Sequence[0]
+		assert.Equal(t, "password", m.MatchedWord)
+		assert.Equal(t, 2, m.Diacritics)
+	}
+	// the diacritics are guessed along with the word instead of bruteforcing the password
+	assert.Less(t, result.Guesses, PasswordStrength("pässwörd", nil).Guesses)
+	assert.Greater(t, result.Guesses, e.PasswordStrength("password", nil).Guesses)
+
+	// fullwidth letters are read as their usual form with NFKC
+	e = New(WithNormalization(matching.NFKC))
+	result = e.PasswordStrength("ｐａｓｓｗｏｒｄ", nil)
+	if assert.Len(t, result.Sequence, 1) {
+		assert.Equal(t, "password", result.Sequence[0].MatchedWord)
+		assert.Equal(t, "ｐａｓｓｗｏｒｄ", result.Sequence[0].Token)
+	}
+}
+
+func TestLayoutSwitches(t *testing.T) {
+	e := New(
+		WithDictionary("ru_words", []string{"телефон", "пароль"}),
+		WithLayoutSwitches(matching.LayoutSwitch{Typed: adjacency.Get("qwerty"), Intended: adjacency.Get("jcuken")}),
+	)
+	result := e.PasswordStrength("ntktajy", nil)
+	if assert.Len(t, result.Sequence, 1) {
+		m := result.Sequence[0]
+		assert.Equal(t, "телефон", m.MatchedWord)
+		assert.Equal(t, "ru_words", m.DictionaryName)
+		assert.Equal(t, "jcuken", m.Layout)
+	}
+
+	userInputs := []string{"Светлана"}
+	switched := e.PasswordStrength("Cdtnkfyf", userInputs)
+	if assert.Len(t, switched.Sequence, 1) {
+		m := switched.Sequence[0]
+		assert.Equal(t, "светлана", m.MatchedWord)
+		assert.Equal(t, "jcuken", m.Layout)
+	}
+	assert.Equal(t, "Typing a word with another keyboard layout active doesn't hide it", switched.Feedback.Suggestions[len(switched.Feedback.Suggestions)-1])
+
+	// there are no layout switches by default, the password is bruteforced
+	for _, m := range PasswordStrength("Cdtnkfyf", userInputs).Sequence {
+		assert.Empty(t, m.Layout)
+	}
+	assert.Greater(t, PasswordStrength("Cdtnkfyf", userInputs).Guesses, switched.Guesses)
+}
+
+func TestUserInfo(t *testing.T) {
+	info := matching.UserInfo{
+		Name:      "John Smith",
+		Email:     "john.smith@example.com",
+		Phone:     "+1 (415) 555-0199",
+		BirthDate: time.Date(1985, time.March, 4, 0, 0, 0, 0, time.UTC),
+	}
+	for _, password := range []string{"jsmith0199", "Example04.03.85", "smithjohn5550199", "4march1985John"} {
+		result := PasswordStrength(password, info.Inputs())
+		assert.Equal(t, "user_inputs", result.Sequence[0].DictionaryName, password)
+		assert.Less(t, result.Guesses, PasswordStrength(password, nil).Guesses, password)
+	}
+
+	// the full values are matched as before
+	result := PasswordStrength("john.smith@example.com", []string{"john.smith@example.com"})
+	if assert.Len(t, result.Sequence, 1) {
+		assert.Equal(t, "user_inputs", result.Sequence[0].DictionaryName)
+	}
+}
+
+func TestBreachChecker(t *testing.T) {
+	var prefixes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefixes = append(prefixes, strings.TrimPrefix(r.URL.Path, "/range/"))
+		if r.URL.Path == "/range/"+sha1Prefix("Tr0ub4dour&3") {
+			fmt.Fprintf(w, "%s:42\r\n", sha1Suffix("Tr0ub4dour&3"))
+		}
+	}))
+	defer server.Close()
+	e := New(WithBreachChecker(breach.NewClient(&breach.HTTPTransport{
+		URL:    server.URL + "/range/",
+		Client: server.Client(),
+	})))
+
+	result, err := e.PasswordStrengthContext(context.Background(), "Tr0ub4dour&3", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 42, result.Breaches)
+	assert.Equal(t, 0, result.Score)
+	assert.Equal(t, feedback.GetBreachedFeedback(), result.Feedback)
+	assert.Equal(t, PasswordStrength("Tr0ub4dour&3", nil).Guesses, result.Guesses)
+	assert.Equal(t, []string{sha1Prefix("Tr0ub4dour&3")}, prefixes)
+
+	result, err = e.PasswordStrengthContext(context.Background(), "correcthorsebatterystaple", nil)
+	require.NoError(t, err)
+	assert.Zero(t, result.Breaches)
+	assert.Equal(t, PasswordStrength("correcthorsebatterystaple", nil).Score, result.Score)
+
+	// failed checks leave the estimate alone
+	server.Close()
+	result, err = e.PasswordStrengthContext(context.Background(), "Tr0ub4dour&3", nil)
+	assert.Error(t, err)
+	assert.Zero(t, result.Breaches)
+	assert.Equal(t, PasswordStrength("Tr0ub4dour&3", nil).Score, result.Score)
+	assert.Equal(t, result.Score, e.PasswordStrength("Tr0ub4dour&3", nil).Score)
+}
+
+func sha1Prefix(password string) string {
+	return sha1Hex(password)[:breach.PrefixLength]
+}
+
+func sha1Suffix(password string) string {
+	return sha1Hex(password)[breach.PrefixLength:]
+}
+
+func sha1Hex(password string) string {
+	digest := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(digest[:]))
+}